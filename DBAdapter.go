@@ -13,4 +13,17 @@ func (r DBAdapter) Run(fieldType, operator, column, value string, dbQuery interf
 	return r[fieldType][operator](column, strings.TrimSpace(value), dbQuery, q)
 }
 
-type DBOperations map[string]func(column, value string, dbQuery interface{}, q QueryInterface) (interface{}, error)
+// DBOperation builds one operator condition in the database query
+type DBOperation func(column, value string, dbQuery interface{}, q QueryInterface) (interface{}, error)
+
+type DBOperations map[string]DBOperation
+
+// Clone returns a copy of the operations, used to create new field types from an existing one
+func (r DBOperations) Clone() DBOperations {
+	ops := make(DBOperations, len(r))
+	for name, op := range r {
+		ops[name] = op
+	}
+
+	return ops
+}
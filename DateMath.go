@@ -0,0 +1,155 @@
+package query_parser_to_db
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	dateOnlyLayout = "2006-01-02"
+)
+
+var (
+	// relative date expressions, one anchor and optional offsets like now-7d or startOfMonth-1M+2d.
+	// Query strings decode + as one space, so one space is also one + sign, like now 1d
+	dateExpressionRegexp = regexp.MustCompile(`^(now|today|(?:startOf|endOf)(?:Day|Week|Month|Year))((?:[+ -][0-9]+[smhdwMy])*)$`)
+	dateOffsetRegexp     = regexp.MustCompile(`([+ -])([0-9]+)([smhdwMy])`)
+	// times with one decoded + offset, like 2024-03-01T10:00:00 01:00
+	dateSpaceOffsetRegexp = regexp.MustCompile(`^([0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9:.]+) ([0-9]{2}:[0-9]{2})$`)
+	// offsets that change the time of the day, day expressions with them are not expanded to day ranges
+	dateTimeOffsetRegexp = regexp.MustCompile(`[0-9][smh]`)
+	// absolute date layouts, dates without offset are in the query location
+	dateLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+	}
+)
+
+// ParseDateValue parses one absolute date, like 2024-03-01 or 2024-03-01T10:00:00Z, or one relative date expression
+// evaluated with now and its location:
+//
+//	now, today                                the current time and the start of the current day
+//	startOfDay, startOfWeek, startOfMonth, startOfYear and the endOf versions, weeks start on monday
+//	+N or -N with the units s, m, h, d, w, M and y, like now-7d, today+1d or startOfMonth-1M
+//
+// One space is read as +, the + decoded from query strings without %2B, like now 1d or 2024-03-01T10:00:00 01:00
+func ParseDateValue(value string, now time.Time) (time.Time, error) {
+	if m := dateExpressionRegexp.FindStringSubmatch(value); m != nil {
+		t := dateAnchor(m[1], now)
+
+		for _, offset := range dateOffsetRegexp.FindAllStringSubmatch(m[2], -1) {
+			n, err := strconv.Atoi(offset[2])
+			if err != nil {
+				return t, fmt.Errorf("%w: %s", ErrInvalidQueryValue, value)
+			}
+			if offset[1] == "-" {
+				n = -n
+			}
+			t = addDateOffset(t, n, offset[3])
+		}
+
+		return t, nil
+	}
+
+	// the start of the day, also in days without midnight:
+	if t, err := time.Parse(dateOnlyLayout, value); err == nil {
+		return startOfDate(t.Year(), t.Month(), t.Day(), now.Location()), nil
+	}
+
+	value = dateSpaceOffsetRegexp.ReplaceAllString(value, "$1+$2")
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return now, fmt.Errorf("%w: invalid date %q", ErrInvalidQueryValue, value)
+}
+
+// parseDateRange parses one date value like ParseDateValue. Day values, like 2024-03-01 or today-1d, return the day
+// range from start to the start of the next day in the now location, including DST days with 23 or 25 hours
+func parseDateRange(value string, now time.Time) (start, end time.Time, day bool, err error) {
+	start, err = ParseDateValue(value, now)
+	if err != nil {
+		return start, start, false, err
+	}
+
+	if m := dateExpressionRegexp.FindStringSubmatch(value); m != nil {
+		day = (m[1] == "today" || m[1] == "startOfDay") && !dateTimeOffsetRegexp.MatchString(m[2])
+	} else {
+		_, parseErr := time.Parse(dateOnlyLayout, value)
+		day = parseErr == nil
+	}
+
+	if !day {
+		return start, start, false, nil
+	}
+
+	y, m, d := start.Date()
+	return start, startOfDate(y, m, d+1, start.Location()), true, nil
+}
+
+// startOfDate returns the first time of the date. In DST changes at midnight the day starts at 01:00,
+// time.Date returns the previous day for missing times
+func startOfDate(y int, m time.Month, d int, location *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, location)
+	if t.Hour() == 0 {
+		return t
+	}
+
+	_, before := t.Zone()
+	_, after := t.Add(12 * time.Hour).Zone()
+
+	return t.Add(time.Duration(after-before) * time.Second)
+}
+
+func dateAnchor(anchor string, now time.Time) time.Time {
+	y, m, d := now.Date()
+	startOfDay := startOfDate(y, m, d, now.Location())
+	// days since monday:
+	weekday := (int(now.Weekday()) + 6) % 7
+
+	switch anchor {
+	case "today", "startOfDay":
+		return startOfDay
+	case "endOfDay":
+		return startOfDate(y, m, d+1, now.Location()).Add(-time.Nanosecond)
+	case "startOfWeek":
+		return startOfDate(y, m, d-weekday, now.Location())
+	case "endOfWeek":
+		return startOfDate(y, m, d+7-weekday, now.Location()).Add(-time.Nanosecond)
+	case "startOfMonth":
+		return startOfDate(y, m, 1, now.Location())
+	case "endOfMonth":
+		return startOfDate(y, m+1, 1, now.Location()).Add(-time.Nanosecond)
+	case "startOfYear":
+		return startOfDate(y, 1, 1, now.Location())
+	case "endOfYear":
+		return startOfDate(y+1, 1, 1, now.Location()).Add(-time.Nanosecond)
+	default:
+		return now
+	}
+}
+
+// addDateOffset adds n units, days and longer units are calendar units in the time location
+func addDateOffset(t time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "s":
+		return t.Add(time.Duration(n) * time.Second)
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, n)
+	case "w":
+		return t.AddDate(0, 0, 7*n)
+	case "M":
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(n, 0, 0)
+	}
+}
@@ -0,0 +1,67 @@
+package query_parser_to_db
+
+import (
+	"testing"
+	"time"
+	// time zones for the tests in systems without zoneinfo:
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDateValue(t *testing.T) {
+	assert := assert.New(t)
+
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	assert.Nil(err)
+
+	// wednesday:
+	now := time.Date(2024, 3, 13, 15, 30, 10, 0, time.UTC)
+
+	t.Run("Should parse relative date expressions", func(t *testing.T) {
+		for value, expected := range map[string]time.Time{
+			"now":                 now,
+			"now-7d":              time.Date(2024, 3, 6, 15, 30, 10, 0, time.UTC),
+			"now+1h-30m":          time.Date(2024, 3, 13, 16, 0, 10, 0, time.UTC),
+			"now-10s":             time.Date(2024, 3, 13, 15, 30, 0, 0, time.UTC),
+			"today":               time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC),
+			"today-1w":            time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC),
+			"startOfDay+1d":       time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC),
+			"endOfDay":            time.Date(2024, 3, 13, 23, 59, 59, 999999999, time.UTC),
+			"startOfWeek":         time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+			"endOfWeek":           time.Date(2024, 3, 17, 23, 59, 59, 999999999, time.UTC),
+			"startOfMonth":        time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			"startOfMonth-1M":     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			"endOfMonth":          time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC),
+			"startOfYear-1y":      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			"endOfYear":           time.Date(2024, 12, 31, 23, 59, 59, 999999999, time.UTC),
+			"2024-03-01":          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			"2024-03-01T10:00:00": time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			// + decoded as one space:
+			"now 1h-30m":                time.Date(2024, 3, 13, 16, 0, 10, 0, time.UTC),
+			"today 1w":                  time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+			"2024-03-01T10:00:00 01:00": time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		} {
+			parsed, err := ParseDateValue(value, now)
+			assert.Nil(err, value)
+			assert.True(expected.Equal(parsed), "%s: %s", value, parsed)
+		}
+	})
+
+	t.Run("Should use the location of now", func(t *testing.T) {
+		parsed, err := ParseDateValue("today", now.In(saoPaulo))
+		assert.Nil(err)
+		assert.Equal("2024-03-13T03:00:00Z", parsed.UTC().Format(time.RFC3339))
+
+		parsed, err = ParseDateValue("2024-03-01T10:00:00+01:00", now.In(saoPaulo))
+		assert.Nil(err)
+		assert.Equal("2024-03-01T09:00:00Z", parsed.UTC().Format(time.RFC3339))
+	})
+
+	t.Run("Should return an error for invalid dates", func(t *testing.T) {
+		for _, value := range []string{"", "yesterday", "now-7", "now-7x", "now - 7d", "2024-13-01", "startOfMonth-"} {
+			_, err := ParseDateValue(value, now)
+			assert.ErrorIs(err, ErrInvalidQueryValue, value)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package query_parser_to_db
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// [fieldType]ValueParser
+	valueParsers map[string]ValueParser
+
+	decimalRegexp = regexp.MustCompile(`^([+-]?)([0-9]*)(?:\.([0-9]*))?$`)
+	hexRegexp     = regexp.MustCompile(`^[0-9a-f]{32}$`)
+)
+
+// ValueParser validates and normalizes each filter value of one field type, like the canonical uuid form
+type ValueParser func(value string) (string, error)
+
+// RegisterValueParser sets the value parser of one field type, used in all operators with values of this type.
+// Not safe for concurrent use, register parsers on app bootstrap
+func RegisterValueParser(fieldType string, parse ValueParser) error {
+	if _, ok := GORMDBAdapter[fieldType]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFieldType, fieldType)
+	}
+
+	if parse == nil {
+		delete(valueParsers, fieldType)
+		return nil
+	}
+
+	valueParsers[fieldType] = parse
+
+	return nil
+}
+
+// registerDefaultValueParsers fills the value parsers registry with the uuid, decimal and bigint parsers
+func registerDefaultValueParsers() {
+	valueParsers = map[string]ValueParser{
+		"uuid":    ParseUUID,
+		"decimal": ParseDecimal,
+		"bigint":  ParseBigint,
+	}
+}
+
+// parseFieldValues validates and normalizes the operator values with the field type parser and the allowed values
+func parseFieldValues(cfg *ModelFieldTagConfig, op *Operator, value string) (string, error) {
+	values := []string{value}
+	if op.Arity == ArityList {
+		values = SplitListValue(value)
+	}
+
+	parse := valueParsers[cfg.Type]
+	// unsigned Go fields accept the uint64 range, like uint64 ids:
+	if cfg.Type == "bigint" && cfg.Unsigned {
+		parse = ParseUnsignedBigint
+	}
+
+	for i, v := range values {
+		if parse != nil {
+			parsed, err := parse(v)
+			if err != nil {
+				return "", fmt.Errorf("%w: %s: %s", ErrInvalidQueryValue, cfg.Param, err.Error())
+			}
+			values[i] = parsed
+		}
+
+		if !cfg.AllowsValue(values[i]) {
+			return "", fmt.Errorf("%w: %s accepts %s", ErrInvalidQueryValue, cfg.Param, strings.Join(cfg.Values, ", "))
+		}
+	}
+
+	return strings.Join(values, listSeparator), nil
+}
+
+// ParseUUID returns the canonical lower case form of one uuid, with or without hyphens, braces or the urn:uuid: prefix
+func ParseUUID(value string) (string, error) {
+	v := strings.TrimPrefix(strings.ToLower(value), "urn:uuid:")
+	if strings.HasPrefix(v, "{") && strings.HasSuffix(v, "}") {
+		v = v[1 : len(v)-1]
+	}
+
+	if len(v) == 36 {
+		if v[8] != '-' || v[13] != '-' || v[18] != '-' || v[23] != '-' {
+			return "", fmt.Errorf("invalid uuid %q", value)
+		}
+		v = v[0:8] + v[9:13] + v[14:18] + v[19:23] + v[24:]
+	}
+
+	if !hexRegexp.MatchString(v) {
+		return "", fmt.Errorf("invalid uuid %q", value)
+	}
+
+	return v[0:8] + "-" + v[8:12] + "-" + v[12:16] + "-" + v[16:20] + "-" + v[20:], nil
+}
+
+// ParseDecimal returns the exact decimal without sign plus and leading or trailing zeros, like 12.5 for +012.50.
+// Exponents are not accepted
+func ParseDecimal(value string) (string, error) {
+	m := decimalRegexp.FindStringSubmatch(value)
+	if m == nil || (m[2] == "" && m[3] == "") {
+		return "", fmt.Errorf("invalid decimal %q", value)
+	}
+
+	integer := strings.TrimLeft(m[2], "0")
+	if integer == "" {
+		integer = "0"
+	}
+
+	fraction := strings.TrimRight(m[3], "0")
+
+	decimal := integer
+	if fraction != "" {
+		decimal += "." + fraction
+	}
+
+	if m[1] == "-" && decimal != "0" {
+		decimal = "-" + decimal
+	}
+
+	return decimal, nil
+}
+
+// ParseBigint returns the 64 bits integer, or one error for values out of the bigint range
+func ParseBigint(value string) (string, error) {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return "", fmt.Errorf("%q overflows bigint", value)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid bigint %q", value)
+	}
+
+	return strconv.FormatInt(i, 10), nil
+}
+
+// ParseUnsignedBigint returns the unsigned 64 bits integer, used in bigint fields with unsigned Go types like uint64
+func ParseUnsignedBigint(value string) (string, error) {
+	i, err := strconv.ParseUint(value, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return "", fmt.Errorf("%q overflows unsigned bigint", value)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid unsigned bigint %q", value)
+	}
+
+	return strconv.FormatUint(i, 10), nil
+}
@@ -0,0 +1,151 @@
+package query_parser_to_db
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type FieldTypesModelStub struct {
+	ID     string `json:"id" filter:"param:id;type:uuid"`
+	Price  string `json:"price" filter:"param:price;type:decimal"`
+	Amount int64  `json:"amount" filter:"param:amount;type:bigint"`
+}
+
+func TestFieldTypesParsers(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should normalize uuids", func(t *testing.T) {
+		for value, expected := range map[string]string{
+			"6BA7B810-9DAD-11D1-80B4-00C04FD430C8":          "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}":        "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			"6ba7b8109dad11d180b400c04fd430c8":              "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		} {
+			parsed, err := ParseUUID(value)
+			assert.Nil(err, value)
+			assert.Equal(expected, parsed, value)
+		}
+
+		for _, value := range []string{"", "6ba7b810", "6ba7b810-9dad-11d1-80b4-00c04fd430cz", "6ba7b8109-dad-11d1-80b4-00c04fd430c8"} {
+			_, err := ParseUUID(value)
+			assert.NotNil(err, value)
+		}
+	})
+
+	t.Run("Should normalize exact decimals", func(t *testing.T) {
+		for value, expected := range map[string]string{
+			"12.50":                      "12.5",
+			"+012.50":                    "12.5",
+			"-0.0":                       "0",
+			".5":                         "0.5",
+			"10.":                        "10",
+			"-3":                         "-3",
+			"123456789012345678901.0001": "123456789012345678901.0001",
+		} {
+			parsed, err := ParseDecimal(value)
+			assert.Nil(err, value)
+			assert.Equal(expected, parsed, value)
+		}
+
+		for _, value := range []string{"", ".", "1e5", "1,5", "--1", "NaN"} {
+			_, err := ParseDecimal(value)
+			assert.NotNil(err, value)
+		}
+	})
+
+	t.Run("Should detect bigint overflows", func(t *testing.T) {
+		parsed, err := ParseBigint("+0042")
+		assert.Nil(err)
+		assert.Equal("42", parsed)
+
+		parsed, err = ParseBigint("-9223372036854775808")
+		assert.Nil(err)
+		assert.Equal("-9223372036854775808", parsed)
+
+		_, err = ParseBigint("9223372036854775808")
+		assert.Contains(err.Error(), "overflows bigint")
+
+		_, err = ParseBigint("1.5")
+		assert.NotNil(err)
+
+		parsed, err = ParseUnsignedBigint("18446744073709551615")
+		assert.Nil(err)
+		assert.Equal("18446744073709551615", parsed)
+
+		_, err = ParseUnsignedBigint("18446744073709551616")
+		assert.Contains(err.Error(), "overflows unsigned bigint")
+
+		_, err = ParseUnsignedBigint("-1")
+		assert.NotNil(err)
+	})
+}
+
+func TestFieldTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should filter with normalized values", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?id__in=6BA7B810-9DAD-11D1-80B4-00C04FD430C8,{6ba7b811-9dad-11d1-80b4-00c04fd430c8}&price__gte=010.50&amount__lt=+100&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[FieldTypesModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]FieldTypesModelStub{})
+		assert.Equal("SELECT * FROM `field_types_model_stubs` WHERE amount < ? AND id IN (?,?) AND price >= ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"100", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b811-9dad-11d1-80b4-00c04fd430c8", "10.5"}, r.Statement.Vars)
+	})
+
+	t.Run("Should reject invalid values", func(t *testing.T) {
+		for _, rawQuery := range []string{"id=1", "price=1e3", "amount=99999999999999999999", "id__in=6ba7b8109dad11d180b400c04fd430c8,x"} {
+			values, _ := url.ParseQuery(rawQuery)
+
+			q := NewQuery(50)
+			assert.Nil(q.ParseFromURLValues(values))
+
+			_, err := ApplyGORM[FieldTypesModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+			assert.ErrorIs(err, ErrInvalidQueryValue, rawQuery)
+		}
+	})
+
+	t.Run("Should accept the uint64 range in unsigned bigint fields", func(t *testing.T) {
+		q := NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{"id": {"18446744073709551615"}, "limit": {"10"}}))
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal([]interface{}{"18446744073709551615"}, r.Statement.Vars)
+
+		q = NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{"id": {"-1"}}))
+
+		_, err = ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+	})
+
+	t.Run("Should use custom value parsers", func(t *testing.T) {
+		t.Cleanup(resetOperatorsRegistry)
+
+		assert.Nil(RegisterFieldType("slug", "string"))
+		assert.Nil(RegisterValueParser("slug", func(value string) (string, error) {
+			return "slug-" + value, nil
+		}))
+		assert.ErrorIs(RegisterValueParser("unknown", ParseUUID), ErrUnknownFieldType)
+
+		q := NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{"slug": {"hello"}, "limit": {"10"}}))
+
+		query, err := ApplyGORM[SlugModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]SlugModelStub{})
+		assert.Equal([]interface{}{"slug-hello"}, r.Statement.Vars)
+	})
+}
@@ -61,6 +61,43 @@ var gormDBOperations = DBOperations{
 		query := q.(*gorm.DB)
 		query = query.Where(fieldName+" NOT LIKE ?", "%"+value+"%")
 
+		return query, nil
+	},
+	"in": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		query = query.Where(fieldName+" IN ?", SplitListValue(value))
+
+		return query, nil
+	},
+	"not-in": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		query = query.Where(fieldName+" NOT IN ?", SplitListValue(value))
+
+		return query, nil
+	},
+	"search": gormSearch,
+	"gt": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		query = query.Where(fieldName+" > ?", value)
+
+		return query, nil
+	},
+	"gte": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		query = query.Where(fieldName+" >= ?", value)
+
+		return query, nil
+	},
+	"lt": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		query = query.Where(fieldName+" < ?", value)
+
+		return query, nil
+	},
+	"lte": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		query = query.Where(fieldName+" <= ?", value)
+
 		return query, nil
 	},
 }
@@ -96,23 +133,106 @@ func NewGORMDBAdapter() DBAdapter {
 			"not-equal":   gormDBOperations["not-equal"],
 			"is-null":     gormDBOperations["is-null"],
 			"is-not-null": gormDBOperations["is-not-null"],
+			"gt":          gormDBOperations["gt"],
+			"gte":         gormDBOperations["gte"],
+			"lt":          gormDBOperations["lt"],
+			"lte":         gormDBOperations["lte"],
+		},
+		// fixed list of values, checked with the values tag or EnumValuer before the query:
+		"enum": {
+			"equal":       gormDBOperations["equal"],
+			"not-equal":   gormDBOperations["not-equal"],
+			"is-null":     gormDBOperations["is-null"],
+			"is-not-null": gormDBOperations["is-not-null"],
+			"in":          gormDBOperations["in"],
+			"not-in":      gormDBOperations["not-in"],
+		},
+		// full-text search, see gormSearch:
+		"search": {
+			"equal":  gormDBOperations["search"],
+			"search": gormDBOperations["search"],
+		},
+		// JSON columns, with paths like metadata__path.to.key__equal=x, see GORMAdapter_json.go:
+		"json": {
+			"equal":       gormJSONCompare("= ?"),
+			"not-equal":   gormJSONCompare("!= ?"),
+			"is-null":     gormJSONCompare("IS NULL"),
+			"is-not-null": gormJSONCompare("IS NOT NULL"),
+			"has-key":     gormJSONHasKey,
+			"contains":    gormJSONContains,
+		},
+		// global free-text param, the field name is the list of search columns:
+		"freetext": {
+			"match": gormFreeTextSearch,
 		},
 		"pagination": {
 			"pager": func(fieldName, value string, dbQuery interface{}, r QueryInterface) (interface{}, error) {
 				query := dbQuery.(*gorm.DB)
+				// no pagination, see Query.Unpaginated:
+				if r.GetLimit() < 1 {
+					return query, nil
+				}
 				query = query.Limit(int(r.GetLimit())).Offset(r.GetOffset())
 				return query, nil
 			},
 		},
+		"scopes": {
+			// server enforced scopes in their own group, before client filters:
+			"group": func(fieldName, value string, dbQuery interface{}, r QueryInterface) (interface{}, error) {
+				query := dbQuery.(*gorm.DB)
+				scopes := r.GetScopes()
+				if len(scopes) == 0 {
+					return query, nil
+				}
+
+				group := query.Session(&gorm.Session{NewDB: true})
+				for _, scope := range scopes {
+					group = group.Where(scope.Query, scope.Args...)
+				}
+
+				return query.Where(group), nil
+			},
+		},
 	}
+	// uuid, decimal and bigint values are normalized by the field type parser, see FieldTypes.go:
+	GORMDBAdapter["uuid"] = GORMDBAdapter["enum"].Clone()
+	GORMDBAdapter["decimal"] = GORMDBAdapter["number"].Clone()
+	GORMDBAdapter["decimal"]["in"] = gormDBOperations["in"]
+	GORMDBAdapter["decimal"]["not-in"] = gormDBOperations["not-in"]
+	GORMDBAdapter["bigint"] = GORMDBAdapter["decimal"].Clone()
+	// arrays, see GORMAdapter_array.go:
+	GORMDBAdapter["array"] = gormArrayOperations.Clone()
+	GORMDBAdapter["array"]["is-null"] = gormDBOperations["is-null"]
+	GORMDBAdapter["array"]["is-not-null"] = gormDBOperations["is-not-null"]
 	// text and blob here will have same operations like string:
-	GORMDBAdapter["text"] = GORMDBAdapter["string"]
-	GORMDBAdapter["blob"] = GORMDBAdapter["string"]
-	// TODO! add a bette support for JSON
-	GORMDBAdapter["json"] = GORMDBAdapter["default"]
-	// Date for all date like formats:
-	GORMDBAdapter["time"] = GORMDBAdapter["date"]
-	GORMDBAdapter["dateOnly"] = GORMDBAdapter["date"]
+	GORMDBAdapter["text"] = GORMDBAdapter["string"].Clone()
+	GORMDBAdapter["blob"] = GORMDBAdapter["string"].Clone()
+	// Date for all date like formats, see GORMAdapter_date.go:
+	GORMDBAdapter["date"] = newGORMDateOperations(false)
+	GORMDBAdapter["time"] = GORMDBAdapter["date"].Clone()
+	GORMDBAdapter["dateOnly"] = newGORMDateOperations(true)
 
 	return GORMDBAdapter
 }
+
+// ApplyGORM applies the parsed filters and pagination of q to db for the model T
+// and returns the resulting *gorm.DB, without any interface{} cast on the caller side:
+//
+//	query, err := ApplyGORM[ContentModelStub](q, db)
+func ApplyGORM[T any](q QueryInterface, db *gorm.DB) (*gorm.DB, error) {
+	if q == nil || db == nil {
+		return db, ErrInvalidDBQuery
+	}
+
+	result, err := q.SetDatabaseQueryForModel(db, new(T))
+	if err != nil {
+		return db, err
+	}
+
+	query, ok := result.(*gorm.DB)
+	if !ok {
+		return db, ErrInvalidDBQuery
+	}
+
+	return query, nil
+}
@@ -0,0 +1,119 @@
+package query_parser_to_db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// array operations, for Postgres arrays like text[] and JSON arrays in SQLite and MySQL.
+// List operators receive the values joined with listSeparator, like has-any=go,sql
+var gormArrayOperations = DBOperations{
+	"has": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+
+		switch query.Dialector.Name() {
+		case "postgres":
+			return query.Where("? = ANY("+fieldName+")", value), nil
+		case "sqlite":
+			return query.Where("EXISTS (SELECT 1 FROM json_each("+fieldName+") WHERE value = ?)", value), nil
+		case "mysql":
+			return query.Where("JSON_CONTAINS("+fieldName+", JSON_QUOTE(?))", value), nil
+		default:
+			return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+		}
+	},
+	"has-any": gormArrayOverlaps,
+	"has-all": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+		values := uniqueValues(SplitListValue(value))
+		if len(values) == 0 {
+			return query, nil
+		}
+
+		switch query.Dialector.Name() {
+		case "postgres":
+			return query.Where(fieldName+" @> "+postgresArray(values), listArgs(values)...), nil
+		case "sqlite":
+			return query.Where("(SELECT COUNT(DISTINCT value) FROM json_each("+fieldName+") WHERE value IN ?) = ?", values, len(values)), nil
+		case "mysql":
+			list, _ := json.Marshal(values)
+			return query.Where("JSON_CONTAINS("+fieldName+", ?)", string(list)), nil
+		default:
+			return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+		}
+	},
+	"overlaps": gormArrayOverlaps,
+	"length": func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+
+		length, err := strconv.Atoi(value)
+		if err != nil {
+			return query, fmt.Errorf("%w: length: %s", ErrInvalidQueryValue, err.Error())
+		}
+
+		switch query.Dialector.Name() {
+		case "postgres":
+			// cardinality is 0 for empty arrays, array_length is NULL:
+			return query.Where("cardinality("+fieldName+") = ?", length), nil
+		case "sqlite":
+			return query.Where("json_array_length("+fieldName+") = ?", length), nil
+		case "mysql":
+			return query.Where("JSON_LENGTH("+fieldName+") = ?", length), nil
+		default:
+			return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+		}
+	},
+}
+
+// gormArrayOverlaps filters arrays with at least one of the values
+func gormArrayOverlaps(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+	query := q.(*gorm.DB)
+	values := uniqueValues(SplitListValue(value))
+	if len(values) == 0 {
+		return query, nil
+	}
+
+	switch query.Dialector.Name() {
+	case "postgres":
+		return query.Where(fieldName+" && "+postgresArray(values), listArgs(values)...), nil
+	case "sqlite":
+		return query.Where("EXISTS (SELECT 1 FROM json_each("+fieldName+") WHERE value IN ?)", values), nil
+	case "mysql":
+		list, _ := json.Marshal(values)
+		return query.Where("JSON_OVERLAPS("+fieldName+", ?)", string(list)), nil
+	default:
+		return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+	}
+}
+
+func uniqueValues(values []string) []string {
+	unique := make([]string, 0, len(values))
+	found := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		if !found[v] {
+			found[v] = true
+			unique = append(unique, v)
+		}
+	}
+
+	return unique
+}
+
+// postgresArray is one ARRAY constructor with one placeholder for each value, GORM adds slices as (?,?)
+func postgresArray(values []string) string {
+	return "ARRAY[" + strings.TrimSuffix(strings.Repeat("?,", len(values)), ",") + "]"
+}
+
+func listArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+
+	return args
+}
@@ -0,0 +1,50 @@
+//go:build sqlite_json
+// +build sqlite_json
+
+package query_parser_to_db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// run with: go test -tags sqlite_json ./...
+func TestGormArraySQLite(t *testing.T) {
+	assert := assert.New(t)
+
+	db := GetFakeGormDB()
+	assert.Nil(db.AutoMigrate(&ArrayModelStub{}))
+	t.Cleanup(func() {
+		db.Migrator().DropTable(&ArrayModelStub{})
+	})
+
+	assert.Nil(db.Create(&[]ArrayModelStub{
+		{Name: "first", Tags: `["go","sql"]`},
+		{Name: "second", Tags: `["go","js","css"]`},
+		{Name: "third", Tags: `[]`},
+	}).Error)
+
+	for rawQuery, names := range map[string][]string{
+		"tags__has=go":                {"first", "second"},
+		"tags__has-any=sql,css":       {"first", "second"},
+		"tags__overlaps=js,rust":      {"second"},
+		"tags__has-all=go,sql":        {"first"},
+		"tags__has-all=go,sql,go":     {"first"},
+		"tags__has-all=go,rust":       nil,
+		"tags__length=0":              {"third"},
+		"tags__length=3&tags__has=go": {"second"},
+	} {
+		query, err := applyRawQuery[ArrayModelStub](t, rawQuery, db)
+		assert.Nil(err)
+
+		var records []ArrayModelStub
+		assert.Nil(query.Order("id").Find(&records).Error)
+
+		var found []string
+		for _, record := range records {
+			found = append(found, record.Name)
+		}
+		assert.Equal(names, found, rawQuery)
+	}
+}
@@ -0,0 +1,52 @@
+package query_parser_to_db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type ArrayModelStub struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Tags string `json:"tags" filter:"param:tags;type:array"`
+}
+
+func TestGormArray(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, tc := range []struct {
+		rawQuery string
+		dialect  string
+		sql      string
+		vars     []interface{}
+	}{
+		{"tags__has=go", "postgres", "? = ANY(tags)", []interface{}{"go"}},
+		{"tags__has=go", "sqlite", "EXISTS (SELECT 1 FROM json_each(tags) WHERE value = ?)", []interface{}{"go"}},
+		{"tags__has=go", "mysql", "JSON_CONTAINS(tags, JSON_QUOTE(?))", []interface{}{"go"}},
+		{"tags__has-any=go,sql&tags__has-any=go", "postgres", "tags && ARRAY[?,?]", []interface{}{"go", "sql"}},
+		{"tags__overlaps=go,sql", "sqlite", "EXISTS (SELECT 1 FROM json_each(tags) WHERE value IN (?,?))", []interface{}{"go", "sql"}},
+		{"tags__has-any=go,sql", "mysql", "JSON_OVERLAPS(tags, ?)", []interface{}{`["go","sql"]`}},
+		{"tags__has-all=go,sql", "postgres", "tags @> ARRAY[?,?]", []interface{}{"go", "sql"}},
+		{"tags__has-all=go,sql", "sqlite", "(SELECT COUNT(DISTINCT value) FROM json_each(tags) WHERE value IN (?,?)) = ?", []interface{}{"go", "sql", 2}},
+		{"tags__has-all=go,sql", "mysql", "JSON_CONTAINS(tags, ?)", []interface{}{`["go","sql"]`}},
+		{"tags__length=2", "postgres", "cardinality(tags) = ?", []interface{}{2}},
+		{"tags__length=2", "sqlite", "json_array_length(tags) = ?", []interface{}{2}},
+		{"tags__length=2", "mysql", "JSON_LENGTH(tags) = ?", []interface{}{2}},
+	} {
+		t.Run("Should build "+tc.rawQuery+" in "+tc.dialect, func(t *testing.T) {
+			query, err := applyRawQuery[ArrayModelStub](t, tc.rawQuery+"&limit=10", getDialectDryRunDB(tc.dialect))
+			assert.Nil(err)
+
+			r := query.Find(&[]ArrayModelStub{})
+			assert.Equal("SELECT * FROM `array_model_stubs` WHERE "+tc.sql+" LIMIT 10", r.Statement.SQL.String())
+			assert.Equal(tc.vars, r.Statement.Vars)
+		})
+	}
+
+	t.Run("Should return an error for invalid lengths", func(t *testing.T) {
+		_, err := applyRawQuery[ArrayModelStub](t, "tags__length=-1", GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+	})
+}
@@ -0,0 +1,63 @@
+package query_parser_to_db
+
+import "gorm.io/gorm"
+
+// gormDateCompare returns the date operations, values are absolute or relative dates, see ParseDateValue.
+// dateOnly columns are compared with the date in the query location, other columns with the UTC time.
+// Day values, like 2024-03-01, are the UTC range of that day in the query location
+func gormDateCompare(operator string, dateOnly bool) DBOperation {
+	return func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+
+		start, end, day, err := parseDateRange(value, r.Now())
+		if err != nil {
+			return query, err
+		}
+
+		if dateOnly {
+			return query.Where(fieldName+" "+dateOperatorConditions[operator], start.Format(dateOnlyLayout)), nil
+		}
+
+		if !day {
+			return query.Where(fieldName+" "+dateOperatorConditions[operator], start.UTC()), nil
+		}
+
+		start, end = start.UTC(), end.UTC()
+
+		switch operator {
+		case "equal":
+			return query.Where(fieldName+" >= ? AND "+fieldName+" < ?", start, end), nil
+		case "not-equal":
+			return query.Where("("+fieldName+" < ? OR "+fieldName+" >= ?)", start, end), nil
+		case "gt":
+			return query.Where(fieldName+" >= ?", end), nil
+		case "lte":
+			return query.Where(fieldName+" < ?", end), nil
+		default:
+			// gte and lt are the same for days and times:
+			return query.Where(fieldName+" "+dateOperatorConditions[operator], start), nil
+		}
+	}
+}
+
+var dateOperatorConditions = map[string]string{
+	"equal":     "= ?",
+	"not-equal": "!= ?",
+	"gt":        "> ?",
+	"gte":       ">= ?",
+	"lt":        "< ?",
+	"lte":       "<= ?",
+}
+
+func newGORMDateOperations(dateOnly bool) DBOperations {
+	ops := DBOperations{
+		"is-null":     gormDBOperations["is-null"],
+		"is-not-null": gormDBOperations["is-not-null"],
+	}
+
+	for operator := range dateOperatorConditions {
+		ops[operator] = gormDateCompare(operator, dateOnly)
+	}
+
+	return ops
+}
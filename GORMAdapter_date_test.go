@@ -0,0 +1,192 @@
+package query_parser_to_db
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type DateModelStub struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"createdAt" filter:"param:created_at;type:time"`
+	PublishedOn string    `json:"publishedOn" filter:"param:published_on;type:dateOnly"`
+}
+
+func TestGormDate(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 3, 13, 15, 30, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("Should filter relative and absolute dates", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?created_at__gte=now-7d&created_at__lt=2024-03-13T12:00:00Z&published_on__lte=startOfMonth&limit=10")
+
+		q := NewQuery(50, WithClock(clock))
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[DateModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]DateModelStub{})
+		assert.Equal("SELECT * FROM `date_model_stubs` WHERE created_at >= ? AND created_at < ? AND published_on <= ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{
+			time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC),
+			time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+			"2024-03-01",
+		}, r.Statement.Vars)
+	})
+
+	t.Run("Should read the + decoded as one space in query strings", func(t *testing.T) {
+		values, err := url.ParseQuery("created_at__gte=now+1d-2h&created_at__lt=2024-03-20T12:00:00+01:00&published_on__lte=startOfMonth+1M&limit=10")
+		assert.Nil(err)
+		assert.Equal("now 1d-2h", values.Get("created_at__gte"))
+
+		q := NewQuery(50, WithClock(clock))
+		assert.Nil(q.ParseFromURLValues(values))
+
+		query, err := ApplyGORM[DateModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]DateModelStub{})
+		assert.Equal("SELECT * FROM `date_model_stubs` WHERE created_at >= ? AND created_at < ? AND published_on <= ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{
+			time.Date(2024, 3, 14, 13, 30, 0, 0, time.UTC),
+			time.Date(2024, 3, 20, 11, 0, 0, 0, time.UTC),
+			"2024-04-01",
+		}, r.Statement.Vars)
+	})
+
+	t.Run("Should expand day values to the UTC range of the day in the query time zone", func(t *testing.T) {
+		for _, tc := range []struct {
+			rawQuery string
+			sql      string
+			vars     []interface{}
+		}{
+			// -03:00:
+			{"tz=America/Sao_Paulo&created_at=2024-03-01", "created_at >= ? AND created_at < ?", []interface{}{
+				time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 3, 0, 0, 0, time.UTC),
+			}},
+			{"tz=America/Sao_Paulo&created_at__not-equal=2024-03-01", "(created_at < ? OR created_at >= ?)", []interface{}{
+				time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 3, 0, 0, 0, time.UTC),
+			}},
+			{"tz=America/Sao_Paulo&created_at__gt=2024-03-01", "created_at >= ?", []interface{}{time.Date(2024, 3, 2, 3, 0, 0, 0, time.UTC)}},
+			{"tz=America/Sao_Paulo&created_at__lte=2024-03-01", "created_at < ?", []interface{}{time.Date(2024, 3, 2, 3, 0, 0, 0, time.UTC)}},
+			{"tz=America/Sao_Paulo&created_at__lt=today", "created_at < ?", []interface{}{time.Date(2024, 3, 13, 3, 0, 0, 0, time.UTC)}},
+			// DST start, 23 hours day:
+			{"tz=America/New_York&created_at=2024-03-10", "created_at >= ? AND created_at < ?", []interface{}{
+				time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC), time.Date(2024, 3, 11, 4, 0, 0, 0, time.UTC),
+			}},
+			// DST end, 25 hours day:
+			{"tz=America/New_York&created_at=2024-11-03", "created_at >= ? AND created_at < ?", []interface{}{
+				time.Date(2024, 11, 3, 4, 0, 0, 0, time.UTC), time.Date(2024, 11, 4, 5, 0, 0, 0, time.UTC),
+			}},
+			// DST start at midnight, the day starts at 01:00:
+			{"tz=America/Sao_Paulo&created_at=2018-11-04", "created_at >= ? AND created_at < ?", []interface{}{
+				time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC), time.Date(2018, 11, 5, 2, 0, 0, 0, time.UTC),
+			}},
+			// times are not expanded:
+			{"tz=America/Sao_Paulo&created_at=2024-03-01T10:00:00", "created_at = ?", []interface{}{time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)}},
+			{"tz=America/Sao_Paulo&published_on=today", "published_on = ?", []interface{}{"2024-03-13"}},
+		} {
+			values, _ := url.ParseQuery(tc.rawQuery + "&limit=10")
+			q := NewQuery(50, WithClock(clock))
+			assert.Nil(q.ParseFromURLValues(values))
+
+			query, err := ApplyGORM[DateModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+			assert.Nil(err)
+
+			r := query.Find(&[]DateModelStub{})
+			assert.Equal("SELECT * FROM `date_model_stubs` WHERE "+tc.sql+" LIMIT 10", r.Statement.SQL.String(), tc.rawQuery)
+			assert.Equal(tc.vars, r.Statement.Vars, tc.rawQuery)
+		}
+	})
+
+	t.Run("Should expand relative day values sent in query strings", func(t *testing.T) {
+		// the day before the DST start in New York:
+		dstEve := func() time.Time { return time.Date(2024, 3, 9, 15, 0, 0, 0, time.UTC) }
+
+		for _, tc := range []struct {
+			rawQuery string
+			clock    func() time.Time
+			sql      string
+			vars     []interface{}
+		}{
+			{"tz=America/Sao_Paulo&created_at=today+1d", clock, "created_at >= ? AND created_at < ?", []interface{}{
+				time.Date(2024, 3, 14, 3, 0, 0, 0, time.UTC), time.Date(2024, 3, 15, 3, 0, 0, 0, time.UTC),
+			}},
+			{"tz=America/Sao_Paulo&created_at__gt=today+1d", clock, "created_at >= ?", []interface{}{time.Date(2024, 3, 15, 3, 0, 0, 0, time.UTC)}},
+			// 23 hours day:
+			{"tz=America/New_York&created_at=today+1d", dstEve, "created_at >= ? AND created_at < ?", []interface{}{
+				time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC), time.Date(2024, 3, 11, 4, 0, 0, 0, time.UTC),
+			}},
+			{"tz=America/New_York&created_at__lte=startOfDay+1d", dstEve, "created_at < ?", []interface{}{time.Date(2024, 3, 11, 4, 0, 0, 0, time.UTC)}},
+		} {
+			values, _ := url.ParseQuery(tc.rawQuery + "&limit=10")
+			q := NewQuery(50, WithClock(tc.clock))
+			assert.Nil(q.ParseFromURLValues(values))
+
+			// the canonical query string is parsed back to the same filters:
+			encoded, _ := url.ParseQuery(q.Encode())
+			q = NewQuery(50, WithClock(tc.clock))
+			assert.Nil(q.ParseFromURLValues(encoded))
+
+			query, err := ApplyGORM[DateModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+			assert.Nil(err, tc.rawQuery)
+
+			r := query.Find(&[]DateModelStub{})
+			assert.Equal("SELECT * FROM `date_model_stubs` WHERE "+tc.sql+" LIMIT 10", r.Statement.SQL.String(), tc.rawQuery)
+			assert.Equal(tc.vars, r.Statement.Vars, tc.rawQuery)
+		}
+	})
+
+	t.Run("Should return an error for invalid dates", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"created_at__gte": {"last week"}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[DateModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+		assert.Equal(`created_at: query parser: invalid query value: invalid date "last week"`, err.Error())
+	})
+
+	t.Run("Should find records with relative dates", func(t *testing.T) {
+		db := GetFakeGormDB()
+		assert.Nil(db.AutoMigrate(&DateModelStub{}))
+		t.Cleanup(func() {
+			db.Migrator().DropTable(&DateModelStub{})
+		})
+
+		assert.Nil(db.Create(&[]DateModelStub{
+			{Name: "old", CreatedAt: now.AddDate(0, -1, 0), PublishedOn: "2024-02-10"},
+			{Name: "recent", CreatedAt: now.AddDate(0, 0, -2), PublishedOn: "2024-03-11"},
+		}).Error)
+
+		for rawQuery, names := range map[string][]string{
+			"created_at__gte=now-7d":        {"recent"},
+			"created_at__lt=startOfMonth":   {"old"},
+			"published_on__gte=startOfWeek": {"recent"},
+			"published_on=2024-02-10":       {"old"},
+		} {
+			values, _ := url.ParseQuery(rawQuery)
+			q := NewQuery(50, WithClock(clock))
+			assert.Nil(q.ParseFromURLValues(values))
+
+			query, err := ApplyGORM[DateModelStub](q, db)
+			assert.Nil(err)
+
+			var records []DateModelStub
+			assert.Nil(query.Order("id").Find(&records).Error)
+
+			var found []string
+			for _, record := range records {
+				found = append(found, record.Name)
+			}
+			assert.Equal(names, found, rawQuery)
+		}
+	})
+}
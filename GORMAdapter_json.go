@@ -0,0 +1,252 @@
+package query_parser_to_db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// valid JSON path segments, object keys or array indexes. Paths are added in the SQL so nothing else is accepted
+var jsonPathSegmentRegexp = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*|[0-9]+)$`)
+
+// jsonPath is one JSON column and the path inside it, from params like metadata__path.to.key
+type jsonPath struct {
+	Column   string
+	Segments []string
+}
+
+// parseJSONPath parses the column received by the json operations, like metadata.path.to.key
+func parseJSONPath(fieldName string) (*jsonPath, error) {
+	parts := strings.Split(fieldName, ".")
+	p := jsonPath{Column: parts[0]}
+
+	if err := p.append(strings.Join(parts[1:], ".")); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// append adds the dot separated segments in the path
+func (r *jsonPath) append(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		if !jsonPathSegmentRegexp.MatchString(segment) {
+			return fmt.Errorf("%w: invalid json path segment %q", ErrInvalidQueryValue, segment)
+		}
+		r.Segments = append(r.Segments, segment)
+	}
+
+	return nil
+}
+
+func (r *jsonPath) child(segment string) *jsonPath {
+	return &jsonPath{Column: r.Column, Segments: append(append([]string{}, r.Segments...), segment)}
+}
+
+// sqlPath is the SQLite and MySQL path, like '$.path.to[0]'
+func (r *jsonPath) sqlPath() string {
+	path := "$"
+	for _, segment := range r.Segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path += "[" + segment + "]"
+		} else {
+			path += "." + segment
+		}
+	}
+
+	return "'" + path + "'"
+}
+
+// postgresPath is the Postgres text array path used with #> and #>>, like '{path,to,0}'
+func (r *jsonPath) postgresPath() string {
+	return "'{" + strings.Join(r.Segments, ",") + "}'"
+}
+
+// extract is the SQL of the path value as text, or the column if the path is empty
+func (r *jsonPath) extract(dialect string) (string, error) {
+	if len(r.Segments) == 0 {
+		return r.Column, nil
+	}
+
+	switch dialect {
+	case "sqlite":
+		// json_extract returns numbers as integers and reals, cast to compare with the text values:
+		return "CAST(json_extract(" + r.Column + ", " + r.sqlPath() + ") AS TEXT)", nil
+	case "postgres":
+		return r.Column + " #>> " + r.postgresPath(), nil
+	case "mysql":
+		return "JSON_UNQUOTE(JSON_EXTRACT(" + r.Column + ", " + r.sqlPath() + "))", nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	}
+}
+
+// gormJSONCompare returns the json operations that compare the path value, like equal
+func gormJSONCompare(condition string) DBOperation {
+	return func(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+		query := q.(*gorm.DB)
+
+		path, err := parseJSONPath(fieldName)
+		if err != nil {
+			return query, err
+		}
+
+		column, err := path.extract(query.Dialector.Name())
+		if err != nil {
+			return query, err
+		}
+
+		if strings.Contains(condition, "?") {
+			return query.Where(column+" "+condition, value), nil
+		}
+
+		return query.Where(column + " " + condition), nil
+	}
+}
+
+// gormJSONHasKey filters records with the key in the path, the key is the value, like metadata__has-key=path.to.key
+func gormJSONHasKey(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+	query := q.(*gorm.DB)
+
+	path, err := parseJSONPath(fieldName)
+	if err != nil {
+		return query, err
+	}
+	if err := path.append(value); err != nil {
+		return query, err
+	}
+	if len(path.Segments) == 0 {
+		return query, fmt.Errorf("%w: has-key needs one key", ErrInvalidQueryValue)
+	}
+
+	switch query.Dialector.Name() {
+	case "sqlite":
+		// json_type is NULL only for missing keys:
+		return query.Where("json_type(" + path.Column + ", " + path.sqlPath() + ") IS NOT NULL"), nil
+	case "postgres":
+		// #> is one JSON null for keys with null values:
+		return query.Where(path.Column + " #> " + path.postgresPath() + " IS NOT NULL"), nil
+	case "mysql":
+		return query.Where("JSON_CONTAINS_PATH(" + path.Column + ", 'one', " + path.sqlPath() + ")"), nil
+	default:
+		return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+	}
+}
+
+// gormJSONContains filters records with the JSON value in the path, like metadata__contains={"tags":["go"]}
+func gormJSONContains(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+	query := q.(*gorm.DB)
+
+	path, err := parseJSONPath(fieldName)
+	if err != nil {
+		return query, err
+	}
+
+	if !json.Valid([]byte(value)) {
+		return query, fmt.Errorf("%w: contains needs one JSON value", ErrInvalidQueryValue)
+	}
+
+	switch query.Dialector.Name() {
+	case "sqlite":
+		// SQLite has no JSON containment, each value in the document is compared with json_extract:
+		decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+		decoder.UseNumber()
+
+		var document interface{}
+		if err := decoder.Decode(&document); err != nil {
+			return query, fmt.Errorf("%w: %s", ErrInvalidQueryValue, err.Error())
+		}
+
+		return sqliteJSONContains(query, path, document)
+	case "postgres":
+		column := path.Column
+		if len(path.Segments) > 0 {
+			column += " #> " + path.postgresPath()
+		}
+
+		return query.Where(column+" @> ?::jsonb", value), nil
+	case "mysql":
+		return query.Where("JSON_CONTAINS("+path.Column+", ?, "+path.sqlPath()+")", value), nil
+	default:
+		return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+	}
+}
+
+func sqliteJSONContains(query *gorm.DB, path *jsonPath, document interface{}) (*gorm.DB, error) {
+	switch v := document.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if !jsonPathSegmentRegexp.MatchString(key) {
+				return query, fmt.Errorf("%w: invalid json key %q", ErrInvalidQueryValue, key)
+			}
+
+			var err error
+			query, err = sqliteJSONContains(query, path.child(key), v[key])
+			if err != nil {
+				return query, err
+			}
+		}
+
+		return query, nil
+	case []interface{}:
+		for _, item := range v {
+			value, ok := sqliteJSONScalar(item)
+			if !ok {
+				return query, fmt.Errorf("%w: nested arrays and objects in arrays", ErrUnsupportedDialect)
+			}
+
+			if value == nil {
+				query = query.Where("EXISTS (SELECT 1 FROM json_each(" + path.Column + ", " + path.sqlPath() + ") WHERE type = 'null')")
+				continue
+			}
+
+			query = query.Where("EXISTS (SELECT 1 FROM json_each("+path.Column+", "+path.sqlPath()+") WHERE value = ?)", value)
+		}
+
+		return query, nil
+	default:
+		value, _ := sqliteJSONScalar(v)
+		if value == nil {
+			return query.Where("json_type(" + path.Column + ", " + path.sqlPath() + ") = 'null'"), nil
+		}
+
+		return query.Where("json_extract("+path.Column+", "+path.sqlPath()+") = ?", value), nil
+	}
+}
+
+// sqliteJSONScalar converts one JSON scalar to the value returned by json_extract, like 1 for true
+func sqliteJSONScalar(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case nil, string:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+		f, _ := v.Float64()
+		return f, true
+	default:
+		return nil, false
+	}
+}
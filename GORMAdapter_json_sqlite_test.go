@@ -0,0 +1,50 @@
+//go:build sqlite_json
+// +build sqlite_json
+
+package query_parser_to_db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// run with: go test -tags sqlite_json ./...
+func TestGormJSONSQLite(t *testing.T) {
+	assert := assert.New(t)
+
+	db := GetFakeGormDB()
+	assert.Nil(db.AutoMigrate(&JSONModelStub{}))
+	t.Cleanup(func() {
+		db.Migrator().DropTable(&JSONModelStub{})
+	})
+
+	assert.Nil(db.Create(&[]JSONModelStub{
+		{Name: "first", Metadata: `{"user":{"email":"a@example.com","age":30},"tags":["go","sql"],"active":true}`},
+		{Name: "second", Metadata: `{"user":{"age":40},"tags":["js"],"active":false}`},
+		{Name: "third", Metadata: `{"user":{"email":null},"tags":[]}`},
+	}).Error)
+
+	for rawQuery, names := range map[string][]string{
+		"metadata__user.age=30":                   {"first"},
+		"metadata__user.age__not-equal=30":        {"second"},
+		"metadata__user.email__is-null=1":         {"second", "third"},
+		"metadata__user__has-key=email":           {"first", "third"},
+		`metadata__contains={"tags":["go"]}`:      {"first"},
+		`metadata__contains={"active":false}`:     {"second"},
+		`metadata__tags__contains=["sql","go"]`:   {"first"},
+		`metadata__user__contains={"email":null}`: {"third"},
+	} {
+		query, err := applyRawQuery[JSONModelStub](t, rawQuery, db)
+		assert.Nil(err)
+
+		var records []JSONModelStub
+		assert.Nil(query.Order("id").Find(&records).Error)
+
+		var found []string
+		for _, record := range records {
+			found = append(found, record.Name)
+		}
+		assert.Equal(names, found, rawQuery)
+	}
+}
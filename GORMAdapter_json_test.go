@@ -0,0 +1,75 @@
+package query_parser_to_db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type JSONModelStub struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Metadata string `json:"metadata" filter:"param:metadata;type:json"`
+}
+
+func TestGormJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should filter one json path in each dialect", func(t *testing.T) {
+		for dialect, sql := range map[string]string{
+			"sqlite":   "SELECT * FROM `json_model_stubs` WHERE CAST(json_extract(metadata, '$.user.roles[0]') AS TEXT) = ? LIMIT 10",
+			"postgres": "SELECT * FROM `json_model_stubs` WHERE metadata #>> '{user,roles,0}' = ? LIMIT 10",
+			"mysql":    "SELECT * FROM `json_model_stubs` WHERE JSON_UNQUOTE(JSON_EXTRACT(metadata, '$.user.roles[0]')) = ? LIMIT 10",
+		} {
+			query, err := applyRawQuery[JSONModelStub](t, "metadata__user.roles.0__equal=admin&limit=10", getDialectDryRunDB(dialect))
+			assert.Nil(err)
+
+			r := query.Find(&[]JSONModelStub{})
+			assert.Equal(sql, r.Statement.SQL.String(), dialect)
+			assert.Equal([]interface{}{"admin"}, r.Statement.Vars, dialect)
+		}
+	})
+
+	t.Run("Should filter json keys in each dialect", func(t *testing.T) {
+		for dialect, sql := range map[string]string{
+			"sqlite":   "SELECT * FROM `json_model_stubs` WHERE json_type(metadata, '$.user.email') IS NOT NULL LIMIT 10",
+			"postgres": "SELECT * FROM `json_model_stubs` WHERE metadata #> '{user,email}' IS NOT NULL LIMIT 10",
+			"mysql":    "SELECT * FROM `json_model_stubs` WHERE JSON_CONTAINS_PATH(metadata, 'one', '$.user.email') LIMIT 10",
+		} {
+			query, err := applyRawQuery[JSONModelStub](t, "metadata__user__has-key=email&limit=10", getDialectDryRunDB(dialect))
+			assert.Nil(err)
+
+			r := query.Find(&[]JSONModelStub{})
+			assert.Equal(sql, r.Statement.SQL.String(), dialect)
+		}
+	})
+
+	t.Run("Should filter json containment in each dialect", func(t *testing.T) {
+		for dialect, sql := range map[string]string{
+			"sqlite":   "SELECT * FROM `json_model_stubs` WHERE json_extract(metadata, '$.active') = ? AND EXISTS (SELECT 1 FROM json_each(metadata, '$.tags') WHERE value = ?) LIMIT 10",
+			"postgres": "SELECT * FROM `json_model_stubs` WHERE metadata @> ?::jsonb LIMIT 10",
+			"mysql":    "SELECT * FROM `json_model_stubs` WHERE JSON_CONTAINS(metadata, ?, '$') LIMIT 10",
+		} {
+			query, err := applyRawQuery[JSONModelStub](t, `metadata__contains={"tags":["go"],"active":true}&limit=10`, getDialectDryRunDB(dialect))
+			assert.Nil(err)
+
+			r := query.Find(&[]JSONModelStub{})
+			assert.Equal(sql, r.Statement.SQL.String(), dialect)
+		}
+	})
+
+	t.Run("Should return an error for invalid paths and values", func(t *testing.T) {
+		db := GetFakeGormDB().Session(&gorm.Session{DryRun: true})
+
+		_, err := applyRawQuery[JSONModelStub](t, "metadata__user')--.name=x", db)
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+
+		_, err = applyRawQuery[JSONModelStub](t, "metadata__has-key=a'b", db)
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+
+		_, err = applyRawQuery[JSONModelStub](t, "metadata__contains={invalid", db)
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+	})
+
+}
@@ -0,0 +1,161 @@
+package query_parser_to_db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// escapes LIKE wildcards in free-text tokens, with ! as the portable ESCAPE char
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// valid table, column and index names in filter tags, like posts_fts or posts.id
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// searchConfig is the search type configuration from the filter tag, like:
+// `filter:"param:q;type:search;index:posts_fts;columns:title,body;rank:true"`
+type searchConfig struct {
+	// SQLite FTS5 table or Postgres tsvector column
+	Index string
+	// columns to search, defaults to the param name
+	Columns []string
+	// primary key matched with the FTS5 rowid, defaults to id
+	Key string
+	// Postgres text search configuration, defaults to simple
+	Language string
+	// order the results by rank
+	Rank bool
+}
+
+func newSearchConfig(column string, cfg *ModelFieldTagConfig) (*searchConfig, error) {
+	s := searchConfig{
+		Columns:  []string{column},
+		Key:      "id",
+		Language: "simple",
+	}
+
+	if cfg != nil {
+		if v := cfg.Options["index"]; v != "" {
+			s.Index = v
+		}
+		if v := cfg.Options["columns"]; v != "" {
+			s.Columns = strings.Split(v, ",")
+		}
+		if v := cfg.Options["key"]; v != "" {
+			s.Key = v
+		}
+		if v := cfg.Options["language"]; v != "" {
+			s.Language = v
+		}
+		s.Rank = cfg.Options["rank"] == "true"
+	}
+
+	for _, name := range append([]string{s.Key, s.Language}, s.Columns...) {
+		if !identifierRegexp.MatchString(name) {
+			return nil, fmt.Errorf("%w: invalid name %q", ErrInvalidSearchConfig, name)
+		}
+	}
+
+	if s.Index != "" && !identifierRegexp.MatchString(s.Index) {
+		return nil, fmt.Errorf("%w: invalid index %q", ErrInvalidSearchConfig, s.Index)
+	}
+
+	return &s, nil
+}
+
+// fts5MatchQuery quotes each search term so client input can't use the FTS5 query syntax
+func fts5MatchQuery(value string, columns []string, filterColumns bool) string {
+	var terms []string
+	for _, term := range strings.Fields(value) {
+		terms = append(terms, `"`+strings.ReplaceAll(term, `"`, `""`)+`"`)
+	}
+
+	match := strings.Join(terms, " ")
+	if filterColumns {
+		match = "{" + strings.Join(columns, " ") + "} : (" + match + ")"
+	}
+
+	return match
+}
+
+// gormSearch is the full-text search operation, translated to the database dialect full-text predicate
+func gormSearch(fieldName, value string, q interface{}, r QueryInterface) (interface{}, error) {
+	query := q.(*gorm.DB)
+
+	if strings.TrimSpace(value) == "" {
+		return query, nil
+	}
+
+	s, err := newSearchConfig(fieldName, r.GetFieldConfig(fieldName))
+	if err != nil {
+		return query, err
+	}
+
+	var where, rank clause.Expr
+
+	switch query.Dialector.Name() {
+	case "sqlite":
+		if s.Index == "" {
+			return query, fmt.Errorf("%w: the sqlite search needs one FTS5 index", ErrInvalidSearchConfig)
+		}
+
+		cfg := r.GetFieldConfig(fieldName)
+		match := fts5MatchQuery(value, s.Columns, cfg != nil && cfg.Options["columns"] != "")
+
+		where = gorm.Expr(s.Key+" IN (SELECT rowid FROM "+s.Index+" WHERE "+s.Index+" MATCH ?)", match)
+		rank = gorm.Expr("(SELECT rank FROM "+s.Index+" WHERE "+s.Index+" MATCH ? AND rowid = "+s.Key+")", match)
+	case "postgres":
+		vector := s.Index
+		if vector == "" {
+			var columns []string
+			for _, c := range s.Columns {
+				columns = append(columns, "coalesce("+c+", '')")
+			}
+			vector = "to_tsvector('" + s.Language + "', " + strings.Join(columns, " || ' ' || ") + ")"
+		}
+		tsQuery := "plainto_tsquery('" + s.Language + "', ?)"
+
+		where = gorm.Expr(vector+" @@ "+tsQuery, value)
+		rank = gorm.Expr("ts_rank("+vector+", "+tsQuery+") DESC", value)
+	case "mysql":
+		match := "MATCH (" + strings.Join(s.Columns, ", ") + ") AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+		where = gorm.Expr(match, value)
+		rank = gorm.Expr(match+" DESC", value)
+	default:
+		return query, fmt.Errorf("%w: %s", ErrUnsupportedDialect, query.Dialector.Name())
+	}
+
+	query = query.Where(where)
+	if s.Rank {
+		query = query.Clauses(clause.OrderBy{Expression: rank})
+	}
+
+	return query, nil
+}
+
+// gormFreeTextSearch matches each value token in any of the columns, case-insensitive.
+// All tokens must match, like (title LIKE %a% OR body LIKE %a%) AND (title LIKE %b% OR body LIKE %b%)
+func gormFreeTextSearch(columns, value string, q interface{}, r QueryInterface) (interface{}, error) {
+	query := q.(*gorm.DB)
+
+	for _, token := range strings.Fields(value) {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(token)) + "%"
+
+		group := query.Session(&gorm.Session{NewDB: true})
+		for i, column := range SplitListValue(columns) {
+			if i == 0 {
+				group = group.Where("LOWER("+column+") LIKE ? ESCAPE '!'", pattern)
+			} else {
+				group = group.Or("LOWER("+column+") LIKE ? ESCAPE '!'", pattern)
+			}
+		}
+
+		query = query.Where(group)
+	}
+
+	return query, nil
+}
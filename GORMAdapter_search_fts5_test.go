@@ -0,0 +1,49 @@
+//go:build sqlite_fts5
+// +build sqlite_fts5
+
+package query_parser_to_db
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// run with: go test -tags sqlite_fts5 ./...
+func TestGormSearchSQLiteFTS5(t *testing.T) {
+	assert := assert.New(t)
+
+	db := GetFakeGormDB()
+	err := db.AutoMigrate(&SearchModelStub{})
+	assert.Nil(err)
+
+	err = db.Exec("CREATE VIRTUAL TABLE IF NOT EXISTS search_model_stubs_fts USING fts5(title, body, content='search_model_stubs', content_rowid='id')").Error
+	assert.Nil(err)
+
+	err = db.Create(&[]SearchModelStub{
+		{Title: "Go query parser", Body: "parse url params"},
+		{Title: "Cooking", Body: "a long text about pasta, sauces and cheese with one query and one parser word"},
+		{Title: "Gardening", Body: "nothing to see here"},
+	}).Error
+	assert.Nil(err)
+
+	err = db.Exec("INSERT INTO search_model_stubs_fts(search_model_stubs_fts) VALUES('rebuild')").Error
+	assert.Nil(err)
+
+	parsedURL, _ := url.Parse("https://example.com/example?q=query parser&limit=10")
+
+	q := NewQuery(50)
+	err = q.ParseFromURLValues(parsedURL.Query())
+	assert.Nil(err)
+
+	query, err := ApplyGORM[SearchModelStub](q, db)
+	assert.Nil(err)
+
+	records := []SearchModelStub{}
+	err = query.Find(&records).Error
+	assert.Nil(err)
+
+	assert.Equal(2, len(records))
+	assert.Equal("Go query parser", records[0].Title)
+}
@@ -0,0 +1,211 @@
+package query_parser_to_db
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type SearchModelStub struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title" filter:"param:q;type:search;index:search_model_stubs_fts;columns:title,body;rank:true"`
+	Body  string `json:"body"`
+}
+
+type PlainSearchModelStub struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title" filter:"param:title;type:search"`
+}
+
+// dialectorStub changes the dialect name to test the SQL of other databases with DryRun
+type dialectorStub struct {
+	gorm.Dialector
+	name string
+}
+
+func (d dialectorStub) Name() string {
+	return d.name
+}
+
+func getDialectDryRunDB(name string) *gorm.DB {
+	db := GetFakeGormDB().Session(&gorm.Session{DryRun: true})
+	config := *db.Config
+	config.Dialector = dialectorStub{Dialector: db.Dialector, name: name}
+	db.Config = &config
+	return db
+}
+
+func TestGormSearch(t *testing.T) {
+	assert := assert.New(t)
+
+	parsedURL, _ := url.Parse(`https://example.com/example?q=hello "world&limit=10`)
+
+	t.Run("Should generate a sqlite FTS5 search with rank", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[SearchModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]SearchModelStub{})
+		assert.Equal("SELECT * FROM `search_model_stubs` WHERE id IN (SELECT rowid FROM search_model_stubs_fts WHERE search_model_stubs_fts MATCH ?) ORDER BY (SELECT rank FROM search_model_stubs_fts WHERE search_model_stubs_fts MATCH ? AND rowid = id) LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{`{title body} : ("hello" """world")`, `{title body} : ("hello" """world")`}, r.Statement.Vars)
+	})
+
+	t.Run("Should generate a postgres tsvector search", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[SearchModelStub](q, getDialectDryRunDB("postgres"))
+		assert.Nil(err)
+
+		r := query.Find(&[]SearchModelStub{})
+		assert.Equal("SELECT * FROM `search_model_stubs` WHERE search_model_stubs_fts @@ plainto_tsquery('simple', ?) ORDER BY ts_rank(search_model_stubs_fts, plainto_tsquery('simple', ?)) DESC LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{`hello "world`, `hello "world`}, r.Statement.Vars)
+	})
+
+	t.Run("Should generate a postgres search without tsvector column", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"title__search": {"hello"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		query, err := ApplyGORM[PlainSearchModelStub](q, getDialectDryRunDB("postgres"))
+		assert.Nil(err)
+
+		r := query.Find(&[]PlainSearchModelStub{})
+		assert.Equal("SELECT * FROM `plain_search_model_stubs` WHERE to_tsvector('simple', coalesce(title, '')) @@ plainto_tsquery('simple', ?) LIMIT 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should generate a mysql MATCH search", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[SearchModelStub](q, getDialectDryRunDB("mysql"))
+		assert.Nil(err)
+
+		r := query.Find(&[]SearchModelStub{})
+		assert.Equal("SELECT * FROM `search_model_stubs` WHERE MATCH (title, body) AGAINST (? IN NATURAL LANGUAGE MODE) ORDER BY MATCH (title, body) AGAINST (? IN NATURAL LANGUAGE MODE) DESC LIMIT 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should return an error for sqlite search without index", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"title": {"hello"}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[PlainSearchModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidSearchConfig)
+	})
+
+	t.Run("Should return an error for unsupported dialects", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"title": {"hello"}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[PlainSearchModelStub](q, getDialectDryRunDB("sqlserver"))
+		assert.ErrorIs(err, ErrUnsupportedDialect)
+	})
+}
+
+type FreeTextModelStub struct {
+	ID     uint64 `json:"id"`
+	Title  string `json:"title" filter:"param:title;type:string;search"`
+	Body   string `json:"body" filter:"search"`
+	Email  string `json:"email" filter:"param:email;search"`
+	Status string `json:"status" filter:"param:status"`
+}
+
+func TestFreeTextSearch(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should match each token in any search field with the other filters", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?q=Hello+50%25_off&status=active&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]FreeTextModelStub{})
+		assert.Equal("SELECT * FROM `free_text_model_stubs` WHERE status = ? AND (LOWER(Body) LIKE ? ESCAPE '!' OR LOWER(email) LIKE ? ESCAPE '!' OR LOWER(title) LIKE ? ESCAPE '!') AND (LOWER(Body) LIKE ? ESCAPE '!' OR LOWER(email) LIKE ? ESCAPE '!' OR LOWER(title) LIKE ? ESCAPE '!') LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"active", "%hello%", "%hello%", "%hello%", "%50!%!_off%", "%50!%!_off%", "%50!%!_off%"}, r.Statement.Vars)
+	})
+
+	t.Run("Should use the search param option", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?search=hello&q=other&limit=10")
+
+		q := NewQuery(50, WithSearchParam("search"))
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]FreeTextModelStub{})
+		assert.Equal("SELECT * FROM `free_text_model_stubs` WHERE (LOWER(Body) LIKE ? ESCAPE '!' OR LOWER(email) LIKE ? ESCAPE '!' OR LOWER(title) LIKE ? ESCAPE '!') LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"%hello%", "%hello%", "%hello%"}, r.Statement.Vars)
+	})
+
+	t.Run("Should ignore blank values and models without search fields", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"q": {"  "}, "limit": {"10"}})
+		assert.Nil(err)
+
+		query, err := ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+		r := query.Find(&[]FreeTextModelStub{})
+		assert.Equal("SELECT * FROM `free_text_model_stubs` LIMIT 10", r.Statement.SQL.String())
+
+		q = NewQuery(50)
+		err = q.ParseFromURLValues(url.Values{"q": {"hello"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		query, err = ApplyGORM[PlainSearchModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+		r = query.Find(&[]PlainSearchModelStub{})
+		assert.Equal("SELECT * FROM `plain_search_model_stubs` LIMIT 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should count each token in each search field in the query limits", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"q": {"a b"}, "status": {"active"}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+		// 2 tokens in 3 fields, 5 each like contains, and status:
+		assert.Equal(2*3*5+1, q.Complexity())
+
+		q = NewQuery(50, WithLimits(QueryLimits{MaxLikeFilters: 1, MaxComplexity: 10}))
+		err = q.ParseFromURLValues(url.Values{"q": {"hello"}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrQueryTooComplex)
+		assert.Contains(err.Error(), "3 like filters, max 1")
+	})
+
+	t.Run("Should reject search params with too many tokens", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"q": {strings.Repeat("a ", 40)}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrQueryTooComplex)
+		assert.Contains(err.Error(), "q has 40 terms, max 10")
+
+		q = NewQuery(50, WithMaxSearchTerms(2))
+		err = q.ParseFromURLValues(url.Values{"q": {"a b c"}})
+		assert.Nil(err)
+
+		_, err = ApplyGORM[FreeTextModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrQueryTooComplex)
+	})
+}
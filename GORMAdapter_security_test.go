@@ -16,7 +16,7 @@ func TestGORMAdapterSecurity(t *testing.T) {
 	assert.Nil(err)
 
 	t.Run("Should ignore contains params if has 'drop table users;' dryRun", func(t *testing.T) {
-		urlString := "https://example.com/example?title_contains='he;drop table users;'&limit=5&page=1"
+		urlString := "https://example.com/example?title__contains='he;drop table users;'&limit=5&page=1"
 		parsedURL, _ := url.Parse(urlString)
 		// rawParamName
 		q := NewQuery(50)
@@ -35,7 +35,7 @@ func TestGORMAdapterSecurity(t *testing.T) {
 		r := query.Find(&records)
 
 		assert.Nil(r.Error)
-		assert.Equal("SELECT * FROM `content_model_stubs`", r.Statement.SQL.String())
+		assert.Equal("SELECT * FROM `content_model_stubs` LIMIT 5", r.Statement.SQL.String())
 		assert.Equal(0, len(r.Statement.Vars))
 
 		query.DryRun = false
@@ -61,7 +61,7 @@ func TestGORMAdapterSecurity(t *testing.T) {
 		r := query.Find(&records)
 
 		assert.Nil(r.Error)
-		assert.Equal("SELECT * FROM `content_model_stubs`", r.Statement.SQL.String())
+		assert.Equal("SELECT * FROM `content_model_stubs` LIMIT 5", r.Statement.SQL.String())
 		assert.Equal(0, len(r.Statement.Vars))
 
 		query.DryRun = false
@@ -180,4 +180,114 @@ func TestGormDBOperations(t *testing.T) {
 
 		db.DryRun = false
 	})
+
+	t.Run("Should generate a valid gt query", func(t *testing.T) {
+		fieldName, value := "clickCount", "2"
+		db.DryRun = true
+
+		queryI, err := gormDBOperations["gt"](fieldName, value, db, q)
+		assert.Nil(err)
+
+		query := queryI.(*gorm.DB)
+		query.Find(&[]ContentModelStub{})
+
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE clickCount > ?", query.Statement.SQL.String())
+		assert.Equal([]interface{}{"2"}, query.Statement.Vars)
+
+		db.DryRun = false
+	})
+
+	t.Run("Should generate a valid gte query", func(t *testing.T) {
+		fieldName, value := "clickCount", "2"
+		db.DryRun = true
+
+		queryI, err := gormDBOperations["gte"](fieldName, value, db, q)
+		assert.Nil(err)
+
+		query := queryI.(*gorm.DB)
+		query.Find(&[]ContentModelStub{})
+
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE clickCount >= ?", query.Statement.SQL.String())
+		assert.Equal([]interface{}{"2"}, query.Statement.Vars)
+
+		db.DryRun = false
+	})
+
+	t.Run("Should generate a valid lt query", func(t *testing.T) {
+		fieldName, value := "clickCount", "20"
+		db.DryRun = true
+
+		queryI, err := gormDBOperations["lt"](fieldName, value, db, q)
+		assert.Nil(err)
+
+		query := queryI.(*gorm.DB)
+		query.Find(&[]ContentModelStub{})
+
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE clickCount < ?", query.Statement.SQL.String())
+		assert.Equal([]interface{}{"20"}, query.Statement.Vars)
+
+		db.DryRun = false
+	})
+
+	t.Run("Should generate a valid lte query", func(t *testing.T) {
+		fieldName, value := "clickCount", "20"
+		db.DryRun = true
+
+		queryI, err := gormDBOperations["lte"](fieldName, value, db, q)
+		assert.Nil(err)
+
+		query := queryI.(*gorm.DB)
+		query.Find(&[]ContentModelStub{})
+
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE clickCount <= ?", query.Statement.SQL.String())
+		assert.Equal([]interface{}{"20"}, query.Statement.Vars)
+
+		db.DryRun = false
+	})
+}
+
+func TestApplyGORM(t *testing.T) {
+	assert := assert.New(t)
+
+	db := GetFakeGormDB()
+	err := db.AutoMigrate(&ContentModelStub{})
+	assert.Nil(err)
+
+	t.Run("Should return a *gorm.DB with filters and pagination", func(t *testing.T) {
+		urlString := "https://example.com/example?title__contains=Hello&limit=5&page=3"
+		parsedURL, _ := url.Parse(urlString)
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Nil(r.Error)
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE title LIKE ? LIMIT 5 OFFSET 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"%Hello%"}, r.Statement.Vars)
+	})
+
+	t.Run("Should return an error for a nil db", func(t *testing.T) {
+		q := NewQuery(50)
+
+		_, err := ApplyGORM[ContentModelStub](q, nil)
+		assert.ErrorIs(err, ErrInvalidDBQuery)
+	})
+
+	t.Run("Should return an error if the model is not a struct", func(t *testing.T) {
+		q := NewQuery(50)
+
+		_, err := ApplyGORM[*ContentModelStub](q, GetFakeGormDB())
+		assert.ErrorIs(err, ErrInvalidModel)
+	})
+
+	t.Run("Should return an error if SetDatabaseQueryForModel receives a non gorm query", func(t *testing.T) {
+		q := NewQuery(50)
+
+		_, err := q.SetDatabaseQueryForModel("SELECT 1", &ContentModelStub{})
+		assert.ErrorIs(err, ErrInvalidDBQuery)
+	})
 }
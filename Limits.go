@@ -0,0 +1,153 @@
+package query_parser_to_db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QueryLimits bounds the client query complexity, use 0 for no limit
+type QueryLimits struct {
+	// max number of filters
+	MaxFilters int
+	// max number of values in one filter, like the IN list size
+	MaxValues int
+	// max length of each value
+	MaxValueLength int
+	// max number of LIKE filters, like contains
+	MaxLikeFilters int
+	// max number of segments in one param name, with the separator and the json path dots, like a__b__c or a__b.c.d
+	MaxDepth int
+	// max complexity score, see Query.Complexity
+	MaxComplexity int
+}
+
+// QueryComplexityError is returned by ParseFromURLValues for queries over the QueryLimits
+type QueryComplexityError struct {
+	Reason string
+	// Score is the query complexity score, see Query.Complexity
+	Score int
+}
+
+func (e *QueryComplexityError) Error() string {
+	return fmt.Sprintf("%s: %s (complexity %d)", ErrQueryTooComplex.Error(), e.Reason, e.Score)
+}
+
+func (e *QueryComplexityError) Unwrap() error {
+	return ErrQueryTooComplex
+}
+
+// Complexity returns the query complexity score, the sum of the operator cost of each filter value.
+// Each word of the search param in each search field costs like one contains filter after SetDatabaseQueryForModel
+func (r *Query) Complexity() int {
+	score := 0
+
+	for _, f := range r.Fields {
+		if r.isFreeTextSearch(f) {
+			cost := 1
+			if op := GetOperator("contains"); op != nil && op.Cost > 0 {
+				cost = op.Cost
+			}
+
+			score += cost * len(searchTerms(f)) * r.searchColumns
+			continue
+		}
+
+		cost := 1
+		if op := GetOperator(f.Operator); op != nil && op.Cost > 0 {
+			cost = op.Cost
+		}
+
+		score += cost * len(r.filterValues(f))
+	}
+
+	return score
+}
+
+// filterValues returns the filter values, with comma separated values of list operators
+func (r *Query) filterValues(f QueryAttr) []string {
+	op := GetOperator(f.Operator)
+	if op == nil || op.Arity != ArityList {
+		return f.Values
+	}
+
+	var values []string
+	for _, v := range f.Values {
+		values = append(values, strings.Split(v, listSeparator)...)
+	}
+
+	return values
+}
+
+// isFreeTextSearch returns true for the search param if it matches the search fields of the current model
+func (r *Query) isFreeTextSearch(f QueryAttr) bool {
+	return r.searchColumns > 0 && f.ParamName == r.searchParam()
+}
+
+// searchTerms returns the words of the search param, each one is matched in all search fields
+func searchTerms(f QueryAttr) []string {
+	return strings.Fields(strings.Join(f.Values, " "))
+}
+
+// paramDepth returns the number of segments in one param name, json paths like metadata__user.roles.0 have 4
+func (r *Query) paramDepth(paramName string) int {
+	depth := 0
+	for _, part := range strings.Split(paramName, r.separator()) {
+		depth += len(strings.Split(part, "."))
+	}
+
+	return depth
+}
+
+// checkLimits returns one QueryComplexityError if the parsed query is over the QueryLimits
+func (r *Query) checkLimits() error {
+	l := r.Limits
+	score := r.Complexity()
+	likeFilters := 0
+
+	fail := func(reason string, args ...interface{}) error {
+		return &QueryComplexityError{Reason: fmt.Sprintf(reason, args...), Score: score}
+	}
+
+	if l.MaxFilters > 0 && len(r.Fields) > l.MaxFilters {
+		return fail("%d filters, max %d", len(r.Fields), l.MaxFilters)
+	}
+
+	for _, f := range r.Fields {
+		values := r.filterValues(f)
+		if l.MaxValues > 0 && len(values) > l.MaxValues {
+			return fail("%s has %d values, max %d", f.ParamName, len(values), l.MaxValues)
+		}
+
+		for _, v := range values {
+			if l.MaxValueLength > 0 && len(v) > l.MaxValueLength {
+				return fail("%s value has %d characters, max %d", f.ParamName, len(v), l.MaxValueLength)
+			}
+		}
+
+		depth := r.paramDepth(f.ParamName)
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			return fail("%s has depth %d, max %d", f.ParamName, depth, l.MaxDepth)
+		}
+
+		if r.isFreeTextSearch(f) {
+			terms := len(searchTerms(f))
+			if terms > r.maxSearchTerms() {
+				return fail("%s has %d terms, max %d", f.ParamName, terms, r.maxSearchTerms())
+			}
+
+			likeFilters += terms * r.searchColumns
+		} else if op := GetOperator(f.Operator); op != nil && op.Like {
+			likeFilters++
+		}
+	}
+
+	if l.MaxLikeFilters > 0 && likeFilters > l.MaxLikeFilters {
+		return fail("%d like filters, max %d", likeFilters, l.MaxLikeFilters)
+	}
+
+	if l.MaxComplexity > 0 && score > l.MaxComplexity {
+		return fail("max complexity %d", l.MaxComplexity)
+	}
+
+	return nil
+}
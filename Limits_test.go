@@ -0,0 +1,95 @@
+package query_parser_to_db
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should return the complexity score", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{
+			"title__contains":   {"a"},
+			"body__starts-with": {"b"},
+			"clickCount__gte":   {"1"},
+			"id":                {"1", "2"},
+		})
+		assert.Nil(err)
+		assert.Equal(5+2+1+2, q.Complexity())
+	})
+
+	cases := []struct {
+		name   string
+		limits QueryLimits
+		values url.Values
+		reason string
+	}{
+		{
+			"Should limit the number of filters",
+			QueryLimits{MaxFilters: 2},
+			url.Values{"a": {"1"}, "b": {"1"}, "c": {"1"}},
+			"3 filters, max 2",
+		},
+		{
+			"Should limit the number of values",
+			QueryLimits{MaxValues: 2},
+			url.Values{"id": {"1", "2", "3"}},
+			"id has 3 values, max 2",
+		},
+		{
+			"Should limit the value length",
+			QueryLimits{MaxValueLength: 5},
+			url.Values{"title__contains": {"123456"}},
+			"title value has 6 characters, max 5",
+		},
+		{
+			"Should limit the number of like filters",
+			QueryLimits{MaxLikeFilters: 1},
+			url.Values{"title__contains": {"a"}, "body__ends-with": {"b"}},
+			"2 like filters, max 1",
+		},
+		{
+			"Should limit the param depth",
+			QueryLimits{MaxDepth: 2},
+			url.Values{"a__b__c__equal": {"1"}},
+			"a__b__c has depth 3, max 2",
+		},
+		{
+			"Should limit the json path depth",
+			QueryLimits{MaxDepth: 3},
+			url.Values{"metadata__a.b.c.d__equal": {"1"}},
+			"metadata__a.b.c.d has depth 5, max 3",
+		},
+		{
+			"Should limit the complexity score",
+			QueryLimits{MaxComplexity: 9},
+			url.Values{"title__contains": {"a"}, "body__contains": {"b"}},
+			"max complexity 9",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := NewQuery(50, WithLimits(c.limits))
+			err := q.ParseFromURLValues(c.values)
+			assert.ErrorIs(err, ErrQueryTooComplex)
+			assert.True(IsValidationError(err))
+
+			var complexityErr *QueryComplexityError
+			assert.True(errors.As(err, &complexityErr))
+			assert.Equal(c.reason, complexityErr.Reason)
+			assert.Equal(q.Complexity(), complexityErr.Score)
+		})
+	}
+
+	t.Run("Should accept queries under the limits", func(t *testing.T) {
+		q := NewQuery(50, WithLimits(QueryLimits{MaxFilters: 2, MaxLikeFilters: 1, MaxComplexity: 10}))
+		err := q.ParseFromURLValues(url.Values{"title__contains": {"a"}, "id": {"1"}})
+		assert.Nil(err)
+	})
+}
@@ -0,0 +1,107 @@
+package query_parser_to_db
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// ListMeta is the pagination metadata of one list response
+type ListMeta struct {
+	Count int64            `json:"count"`
+	Page  int64            `json:"page"`
+	Limit int64            `json:"limit"`
+	Links *PaginationLinks `json:"links,omitempty"`
+}
+
+// ListResponse is the standard JSON envelope for list responses
+type ListResponse[T any] struct {
+	Records []T      `json:"records"`
+	Meta    ListMeta `json:"meta"`
+}
+
+// FindList applies the query in db for the model T, counts all matching records and loads the current page.
+// baseURL is used to build the pagination links, use an empty baseURL to skip them
+func FindList[T any](q QueryInterface, db *gorm.DB, baseURL string) (*ListResponse[T], error) {
+	if db == nil {
+		return nil, ErrInvalidDBQuery
+	}
+
+	query, err := ApplyGORM[T](q, db.Model(new(T)))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := ListResponse[T]{
+		Records: []T{},
+		Meta: ListMeta{
+			Page:  q.GetPage(),
+			Limit: q.GetLimit(),
+		},
+	}
+
+	err = query.Session(&gorm.Session{}).Limit(-1).Offset(-1).Count(&resp.Meta.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&resp.Records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if baseURL != "" {
+		resp.Meta.Links, err = q.PaginationLinks(baseURL, resp.Meta.Count)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &resp, nil
+}
+
+// NewListHandler returns a net/http handler that responds with the ListResponse of the model T.
+// The query is loaded from the request context, see NewMiddleware, or parsed with cfg if not found.
+// Validation errors respond with 400 and the error message, other errors are logged and respond with a generic 500
+func NewListHandler[T any](db *gorm.DB, cfg MiddlewareConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		q := FromContext(req.Context())
+		if q == nil {
+			var err error
+			q, err = cfg.ParseRequest(req)
+			if err != nil {
+				WriteJSONError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		resp, err := FindList[T](q, db.WithContext(req.Context()), req.URL.Path)
+		if err != nil {
+			if IsValidationError(err) {
+				WriteJSONError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			// database errors can have table names and SQL, log them and send one generic message:
+			log.Printf("query parser: list %s: %v", req.URL.Path, err)
+			WriteJSONError(w, http.StatusInternalServerError, errors.New(http.StatusText(http.StatusInternalServerError)))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Meta.Links != nil {
+			w.Header().Set("Link", resp.Meta.Links.LinkHeader())
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// WriteJSONError writes one {"error": "message"} JSON response
+func WriteJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
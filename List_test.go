@@ -0,0 +1,104 @@
+package query_parser_to_db
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gorm_logger "gorm.io/gorm/logger"
+)
+
+// MissingListModelStub has no table in the list test database
+type MissingListModelStub struct {
+	ID uint64 `json:"id"`
+}
+
+type ListModelStub struct {
+	ID    uint64 `json:"id" filter:"param:id;type:number"`
+	Title string `json:"title" filter:"param:title;type:string"`
+}
+
+func GetListFakeGormDB(t *testing.T) *gorm.DB {
+	d, err := gorm.Open(sqlite.Open("file:list_test?mode=memory&cache=shared"), &gorm.Config{
+		Logger: gorm_logger.Default.LogMode(gorm_logger.Warn),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, d.AutoMigrate(&ListModelStub{}))
+	assert.Nil(t, d.Create(&[]ListModelStub{
+		{Title: "Hello 1"}, {Title: "Hello 2"}, {Title: "Hello 3"}, {Title: "Bye"},
+	}).Error)
+
+	return d
+}
+
+func TestFindList(t *testing.T) {
+	assert := assert.New(t)
+	db := GetListFakeGormDB(t)
+
+	t.Run("Should count all records and load the current page", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(map[string][]string{
+			"title__starts-with": {"Hello"},
+			"limit":              {"2"},
+			"page":               {"2"},
+		})
+		assert.Nil(err)
+
+		resp, err := FindList[ListModelStub](q, db, "/posts")
+		assert.Nil(err)
+
+		assert.Equal(int64(3), resp.Meta.Count)
+		assert.Equal(int64(2), resp.Meta.Page)
+		assert.Equal(int64(2), resp.Meta.Limit)
+		assert.Equal(1, len(resp.Records))
+		assert.Equal("Hello 3", resp.Records[0].Title)
+		assert.Equal("/posts?limit=2&page=1&title__starts-with=Hello", resp.Meta.Links.Prev)
+		assert.Equal("", resp.Meta.Links.Next)
+	})
+
+	t.Run("Should respond with the list envelope in the net/http handler", func(t *testing.T) {
+		h := NewListHandler[ListModelStub](db, MiddlewareConfig{LimitMax: 50, DefaultLimit: 2})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts?title__contains=Hello", nil))
+
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.Contains(rec.Header().Get("Link"), `</posts?limit=2&page=2&title__contains=Hello>; rel="next"`)
+
+		resp := ListResponse[ListModelStub]{}
+		assert.Nil(json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(int64(3), resp.Meta.Count)
+		assert.Equal(2, len(resp.Records))
+	})
+
+	t.Run("Should respond with 400 for validation errors", func(t *testing.T) {
+		h := NewListHandler[ListModelStub](db, MiddlewareConfig{LimitMax: 50})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts?limit=abc", nil))
+
+		assert.Equal(http.StatusBadRequest, rec.Code)
+		assert.JSONEq(`{"error": "query parser: invalid query operator"}`, rec.Body.String())
+	})
+	t.Run("Should log database errors and respond with one generic 500", func(t *testing.T) {
+		logs := bytes.Buffer{}
+		log.SetOutput(&logs)
+		t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+		h := NewListHandler[MissingListModelStub](db, MiddlewareConfig{LimitMax: 50})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		assert.Equal(http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(`{"error": "Internal Server Error"}`, rec.Body.String())
+		assert.Contains(logs.String(), "no such table: missing_list_model_stubs")
+	})
+}
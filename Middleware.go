@@ -0,0 +1,97 @@
+package query_parser_to_db
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type contextKey struct{}
+
+var queryContextKey = contextKey{}
+
+// MiddlewareConfig is the net/http middleware configuration
+type MiddlewareConfig struct {
+	LimitMax int64
+	// limit used when the client don't send one, optional
+	DefaultLimit int64
+	// return errors for invalid limit and page params
+	Strict bool
+	// extra NewQuery options, like WithPageParams
+	QueryOptions []QueryOption
+	// Timezone returns the request time zone, like the user profile time zone, optional. The tz param has priority
+	Timezone func(req *http.Request) *time.Location
+	// ErrorRenderer writes the response for invalid queries, defaults to a 400 text response
+	ErrorRenderer func(w http.ResponseWriter, req *http.Request, err error)
+}
+
+// NewMiddleware returns a net/http middleware that parses the request query params once and stores
+// the QueryInterface in the request context, use FromContext to get it in handlers
+func NewMiddleware(cfg MiddlewareConfig) func(next http.Handler) http.Handler {
+	if cfg.ErrorRenderer == nil {
+		cfg.ErrorRenderer = renderHTTPError
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			q, err := cfg.ParseRequest(req)
+			if err != nil {
+				cfg.ErrorRenderer(w, req, err)
+				return
+			}
+
+			next.ServeHTTP(w, req.WithContext(NewContext(req.Context(), q)))
+		})
+	}
+}
+
+// ParseQuery parses the query params in a new query with this configuration
+func (cfg MiddlewareConfig) ParseQuery(values url.Values) (QueryInterface, error) {
+	opts := append([]QueryOption{WithDefaultLimit(cfg.DefaultLimit)}, cfg.QueryOptions...)
+	if cfg.Strict {
+		opts = append(opts, WithParseMode(ParseModeStrict))
+	}
+
+	q := newQuery(cfg.LimitMax, opts...)
+
+	err := q.ParseFromURLValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// ParseRequest parses the request query params like ParseQuery, with the request context and time zone
+func (cfg MiddlewareConfig) ParseRequest(req *http.Request) (QueryInterface, error) {
+	if cfg.Timezone != nil {
+		if location := cfg.Timezone(req); location != nil {
+			cfg.QueryOptions = append(append([]QueryOption{}, cfg.QueryOptions...), WithLocation(location))
+		}
+	}
+
+	q, err := cfg.ParseQuery(req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	q.SetContext(req.Context())
+
+	return q, nil
+}
+
+// NewContext returns a copy of ctx with the query
+func NewContext(ctx context.Context, q QueryInterface) context.Context {
+	return context.WithValue(ctx, queryContextKey, q)
+}
+
+// FromContext returns the query stored by the middleware or nil if not found
+func FromContext(ctx context.Context) QueryInterface {
+	q, _ := ctx.Value(queryContextKey).(QueryInterface)
+	return q
+}
+
+func renderHTTPError(w http.ResponseWriter, req *http.Request, err error) {
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
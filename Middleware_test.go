@@ -0,0 +1,91 @@
+package query_parser_to_db
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	var q QueryInterface
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		q = FromContext(req.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("Should parse the query and store it in the request context", func(t *testing.T) {
+		q = nil
+		m := NewMiddleware(MiddlewareConfig{LimitMax: 50, DefaultLimit: 20})
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/post?title__contains=Hello&page=2", nil)
+		m(handler).ServeHTTP(rec, req)
+
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.NotNil(q)
+		assert.Equal(int64(20), q.GetLimit())
+		assert.Equal(int64(2), q.GetPage())
+		assert.Equal("contains", q.GetParam("title").Operator)
+	})
+
+	t.Run("Should respond with 400 for invalid queries", func(t *testing.T) {
+		q = nil
+		m := NewMiddleware(MiddlewareConfig{LimitMax: 50})
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/post?limit=abc", nil)
+		m(handler).ServeHTTP(rec, req)
+
+		assert.Equal(http.StatusBadRequest, rec.Code)
+		assert.Nil(q)
+	})
+
+	t.Run("Should reject out of range limits in strict mode with the error renderer", func(t *testing.T) {
+		q = nil
+		m := NewMiddleware(MiddlewareConfig{
+			LimitMax: 50,
+			Strict:   true,
+			ErrorRenderer: func(w http.ResponseWriter, req *http.Request, err error) {
+				assert.ErrorIs(err, ErrInvalidQueryValue)
+				w.WriteHeader(http.StatusUnprocessableEntity)
+			},
+		})
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/post?limit=500", nil)
+		m(handler).ServeHTTP(rec, req)
+
+		assert.Equal(http.StatusUnprocessableEntity, rec.Code)
+		assert.Nil(q)
+	})
+
+	t.Run("Should use the request time zone unless the tz param is sent", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		assert.Nil(err)
+
+		m := NewMiddleware(MiddlewareConfig{
+			LimitMax: 50,
+			Timezone: func(req *http.Request) *time.Location {
+				return newYork
+			},
+		})
+
+		q = nil
+		m(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/post", nil))
+		assert.Equal("America/New_York", q.GetLocation().String())
+
+		q = nil
+		m(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/post?tz=America/Sao_Paulo", nil))
+		assert.Equal("America/Sao_Paulo", q.GetLocation().String())
+	})
+
+	t.Run("Should return nil from a context without query", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/post", nil)
+		assert.Nil(FromContext(req.Context()))
+	})
+}
@@ -0,0 +1,274 @@
+package query_parser_to_db
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OperatorArity is the number of values one operator accepts
+type OperatorArity int
+
+const (
+	// operators like is-null, the value is ignored
+	ArityNone OperatorArity = iota
+	// operators like equal, only the first value is used
+	ArityOne
+	// operators like in, all values are used. The builder receives them joined with listSeparator
+	ArityList
+)
+
+const (
+	listSeparator = ","
+)
+
+var (
+	// [operatorName]Operator
+	operators map[string]*Operator
+)
+
+// Operator is one registered query operator, like equal or contains
+type Operator struct {
+	Name string
+	// field types that accept this operator, eg: string, number
+	Types []string
+	Arity OperatorArity
+	// Cost of each value in the query complexity score, defaults to 1
+	Cost int
+	// Like is true for LIKE pattern operators, limited by QueryLimits.MaxLikeFilters
+	Like bool
+	// ParseValue validates and normalizes each raw value before the build, optional
+	ParseValue func(value string) (string, error)
+	// Build adds the operator condition in the GORM query
+	Build DBOperation
+}
+
+// Values returns the value passed to the operator builder from the raw param values
+func (r *Operator) Values(values []string) (string, error) {
+	switch r.Arity {
+	case ArityNone:
+		return "", nil
+	case ArityList:
+		parsed := make([]string, 0, len(values))
+		for _, v := range values {
+			for _, item := range strings.Split(v, listSeparator) {
+				item, err := r.parseValue(strings.TrimSpace(item))
+				if err != nil {
+					return "", err
+				}
+				parsed = append(parsed, item)
+			}
+		}
+
+		return strings.Join(parsed, listSeparator), nil
+	default:
+		if len(values) == 0 {
+			return "", ErrInvalidQueryValue
+		}
+
+		return r.parseValue(strings.TrimSpace(values[0]))
+	}
+}
+
+func (r *Operator) parseValue(value string) (string, error) {
+	if r.ParseValue == nil {
+		return value, nil
+	}
+
+	v, err := r.ParseValue(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %s", ErrInvalidQueryValue, r.Name, err.Error())
+	}
+
+	return v, nil
+}
+
+// SplitListValue splits the value received by ArityList operator builders
+func SplitListValue(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+
+	return strings.Split(value, listSeparator)
+}
+
+// RegisterOperator registers a new operator, or replaces one with the same name, in the query parser
+// and in the GORM adapter for all its field types. Names with the query separator are rejected in ParseFromURLValues.
+// Not safe for concurrent use, register operators on app bootstrap
+func RegisterOperator(op Operator) error {
+	if op.Name == "" || op.Build == nil {
+		return ErrInvalidOperator
+	}
+
+	for _, t := range op.Types {
+		if _, ok := GORMDBAdapter[t]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownFieldType, t)
+		}
+	}
+
+	if old := operators[op.Name]; old != nil {
+		for _, t := range old.Types {
+			delete(GORMDBAdapter[t], op.Name)
+		}
+	}
+
+	for _, t := range op.Types {
+		if GORMDBAdapter[t] == nil {
+			GORMDBAdapter[t] = DBOperations{}
+		}
+		GORMDBAdapter[t][op.Name] = op.Build
+	}
+
+	op.Types = append([]string{}, op.Types...)
+	sort.Strings(op.Types)
+	operators[op.Name] = &op
+
+	return nil
+}
+
+// RegisterFieldType registers a new field type for use in the filter tag, with the same operators and value parser of baseType.
+// Use an empty baseType to create a field type without operators
+func RegisterFieldType(name, baseType string) error {
+	if name == "" {
+		return ErrUnknownFieldType
+	}
+
+	ops := DBOperations{}
+	if baseType != "" {
+		base, ok := GORMDBAdapter[baseType]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownFieldType, baseType)
+		}
+		ops = base.Clone()
+	}
+
+	GORMDBAdapter[name] = ops
+	if parse := valueParsers[baseType]; parse != nil && baseType != "" {
+		valueParsers[name] = parse
+	} else {
+		delete(valueParsers, name)
+	}
+
+	for opName, op := range operators {
+		types := op.Types[:0]
+		for _, t := range op.Types {
+			if t != name {
+				types = append(types, t)
+			}
+		}
+
+		if ops[opName] != nil {
+			types = append(types, name)
+			sort.Strings(types)
+		}
+		op.Types = types
+	}
+
+	return nil
+}
+
+// GetOperator returns the registered operator or nil if not found
+func GetOperator(name string) *Operator {
+	return operators[name]
+}
+
+// AcceptsType returns true if the operator can be used with the field type
+func (r *Operator) AcceptsType(fieldType string) bool {
+	for _, t := range r.Types {
+		if t == fieldType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// registerDefaultOperators fills the operators registry with the GORM adapter operations
+func registerDefaultOperators() {
+	operators = make(map[string]*Operator)
+
+	// sorted field types, so the Build of each operator is the same in all runs:
+	fieldTypes := make([]string, 0, len(GORMDBAdapter))
+	for fieldType := range GORMDBAdapter {
+		if fieldType == "pagination" || fieldType == "scopes" || fieldType == "freetext" {
+			continue
+		}
+		fieldTypes = append(fieldTypes, fieldType)
+	}
+	sort.Strings(fieldTypes)
+
+	for _, fieldType := range fieldTypes {
+		for name, build := range GORMDBAdapter[fieldType] {
+			if operators[name] == nil {
+				operators[name] = &Operator{
+					Name:  name,
+					Arity: ArityOne,
+					Build: build,
+				}
+			}
+			operators[name].Types = append(operators[name].Types, fieldType)
+		}
+	}
+
+	// the generic operations have priority, like equal from gormDBOperations and not from the json type:
+	for name, build := range gormDBOperations {
+		if operators[name] != nil {
+			operators[name].Build = build
+		}
+	}
+
+	operators["is-null"].Arity = ArityNone
+	operators["is-not-null"].Arity = ArityNone
+
+	for _, name := range []string{"in", "not-in", "has-any", "has-all", "overlaps"} {
+		operators[name].Arity = ArityList
+	}
+	operators["length"].ParseValue = func(value string) (string, error) {
+		_, err := strconv.ParseUint(value, 10, 32)
+		return value, err
+	}
+
+	// LIKE filters, the ones with a leading wildcard can't use indexes:
+	for name, cost := range map[string]int{
+		"starts-with":     2,
+		"not-starts-with": 2,
+		"ends-with":       5,
+		"not-ends-with":   5,
+		"contains":        5,
+		"not-contains":    5,
+	} {
+		operators[name].Like = true
+		operators[name].Cost = cost
+	}
+
+	operators["search"].Cost = 5
+}
+
+// checkOperatorNames returns one error if one operator name has the separator, params with it are ambiguous
+func checkOperatorNames(separator string) error {
+	for name := range operators {
+		if strings.Contains(name, separator) {
+			return fmt.Errorf("%w: %s has the separator %s", ErrInvalidOperator, name, separator)
+		}
+	}
+
+	return nil
+}
+
+// parseOperatorSuffix returns the param name and operator from one raw param name like title__contains.
+// The longest operator wins if more than one matches, like not-in and in
+func parseOperatorSuffix(paramName, separator string) (string, string, bool) {
+	found := ""
+	for op := range operators {
+		if len(op) > len(found) && strings.HasSuffix(paramName, separator+op) {
+			found = op
+		}
+	}
+
+	if found == "" {
+		return paramName, "", false
+	}
+
+	return strings.TrimSuffix(paramName, separator+found), found, true
+}
@@ -0,0 +1,172 @@
+package query_parser_to_db
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type SlugModelStub struct {
+	ID   uint64 `json:"id"`
+	Slug string `json:"slug" filter:"param:slug;type:slug"`
+}
+
+func resetOperatorsRegistry() {
+	GORMDBAdapter = NewGORMDBAdapter()
+	registerDefaultOperators()
+	registerDefaultValueParsers()
+}
+
+func TestRegisterOperator(t *testing.T) {
+	assert := assert.New(t)
+	t.Cleanup(resetOperatorsRegistry)
+
+	err := RegisterOperator(Operator{
+		Name:  "ne",
+		Types: []string{"number", "string"},
+		Arity: ArityOne,
+		Build: func(column, value string, dbQuery interface{}, q QueryInterface) (interface{}, error) {
+			return dbQuery.(*gorm.DB).Where(column+" <> ?", value), nil
+		},
+	})
+	assert.Nil(err)
+
+	err = RegisterOperator(Operator{
+		Name:  "between",
+		Types: []string{"number"},
+		Arity: ArityList,
+		ParseValue: func(value string) (string, error) {
+			_, err := strconv.ParseInt(value, 10, 64)
+			return value, err
+		},
+		Build: func(column, value string, dbQuery interface{}, q QueryInterface) (interface{}, error) {
+			values := SplitListValue(value)
+			if len(values) != 2 {
+				return nil, ErrInvalidQueryValue
+			}
+			return dbQuery.(*gorm.DB).Where(column+" BETWEEN ? AND ?", values[0], values[1]), nil
+		},
+	})
+	assert.Nil(err)
+
+	t.Run("Should parse and build a registered operator", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?title__ne=a&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+		assert.Equal("ne", q.GetParam("title").Operator)
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE title <> ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"a"}, r.Statement.Vars)
+	})
+
+	t.Run("Should join list values for list operators", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?clickCount__between=5&clickCount__between=10&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE clickCount BETWEEN ? AND ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"5", "10"}, r.Statement.Vars)
+	})
+
+	t.Run("Should return an error for values rejected by the operator parser", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?clickCount__between=five,10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		_, err = ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+	})
+
+	t.Run("Should keep the built in operators", func(t *testing.T) {
+		assert.True(GetOperator("gte").AcceptsType("date"))
+		assert.True(GetOperator("gte").AcceptsType("decimal"))
+		assert.True(GetOperator("in").AcceptsType("bigint"))
+	})
+
+	t.Run("Should reject invalid operators", func(t *testing.T) {
+		build := gormDBOperations["equal"]
+
+		assert.ErrorIs(RegisterOperator(Operator{Name: "", Build: build}), ErrInvalidOperator)
+		assert.ErrorIs(RegisterOperator(Operator{Name: "gt"}), ErrInvalidOperator)
+		assert.ErrorIs(RegisterOperator(Operator{Name: "gt", Types: []string{"unknown"}, Build: build}), ErrUnknownFieldType)
+	})
+
+	t.Run("Should reject operator names with the query separator in the parse", func(t *testing.T) {
+		assert.Nil(RegisterOperator(Operator{Name: "a.b", Types: []string{"string"}, Build: gormDBOperations["equal"]}))
+
+		q := NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{"title__a.b": {"x"}}))
+		assert.Equal("a.b", q.GetParam("title").Operator)
+
+		q = NewQuery(50, WithSeparator("."))
+		assert.ErrorIs(q.ParseFromURLValues(url.Values{"title.equal": {"x"}}), ErrInvalidOperator)
+		assert.ErrorIs(q.AddQueryParamFromRaw("title.equal", []string{"x"}), ErrInvalidOperator)
+	})
+}
+
+func TestRegisterDefaultOperators(t *testing.T) {
+	assert := assert.New(t)
+	t.Cleanup(resetOperatorsRegistry)
+
+	t.Run("Should use the generic build in operators of many field types", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			resetOperatorsRegistry()
+
+			for name, sql := range map[string]string{
+				"equal":    "SELECT * FROM `content_model_stubs` WHERE title = ?",
+				"contains": "SELECT * FROM `content_model_stubs` WHERE title LIKE ?",
+			} {
+				r, err := GetOperator(name).Build("title", "a", GetFakeGormDB().Session(&gorm.Session{DryRun: true}), NewQuery(50))
+				assert.Nil(err, name)
+
+				query := r.(*gorm.DB)
+				assert.Equal(sql, query.Find(&[]ContentModelStub{}).Statement.SQL.String(), name)
+			}
+		}
+	})
+}
+
+func TestRegisterFieldType(t *testing.T) {
+	assert := assert.New(t)
+	t.Cleanup(resetOperatorsRegistry)
+
+	err := RegisterFieldType("slug", "string")
+	assert.Nil(err)
+	assert.True(GetOperator("contains").AcceptsType("slug"))
+
+	t.Run("Should use the base type operators in the new field type", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?slug__starts-with=hello&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[SlugModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]SlugModelStub{})
+		assert.Equal("SELECT * FROM `slug_model_stubs` WHERE slug LIKE ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"hello%"}, r.Statement.Vars)
+	})
+
+	t.Run("Should return an error for unknown base types", func(t *testing.T) {
+		assert.ErrorIs(RegisterFieldType("slug2", "unknown"), ErrUnknownFieldType)
+	})
+}
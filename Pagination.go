@@ -0,0 +1,177 @@
+package query_parser_to_db
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaginationLinks are the list page URLs with all active filters, empty if the page does not exist
+type PaginationLinks struct {
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// LinkHeader renders the links as one RFC 8288 Link header value
+func (r *PaginationLinks) LinkHeader() string {
+	var links []string
+
+	for _, l := range []struct{ rel, url string }{
+		{"first", r.First},
+		{"prev", r.Prev},
+		{"next", r.Next},
+		{"last", r.Last},
+	} {
+		if l.url != "" {
+			links = append(links, "<"+l.url+`>; rel="`+l.rel+`"`)
+		}
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// PaginationLinks builds the first, prev, next and last page URLs from baseURL and the total records count.
+// Params in baseURL are kept unless the query has a param with the same name
+func (r *Query) PaginationLinks(baseURL string, total int64) (*PaginationLinks, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.PaginationMode == PaginationModeOffset {
+		return r.offsetPaginationLinks(u, total), nil
+	}
+
+	lastPage := int64(1)
+	if r.Limit > 0 && total > r.Limit {
+		lastPage = (total + r.Limit - 1) / r.Limit
+	}
+
+	page := r.GetPage()
+
+	links := PaginationLinks{
+		First: r.pageURL(u, 1),
+		Last:  r.pageURL(u, lastPage),
+	}
+
+	if page > 1 {
+		prev := page - 1
+		if prev > lastPage {
+			prev = lastPage
+		}
+		links.Prev = r.pageURL(u, prev)
+	}
+
+	if page < lastPage {
+		links.Next = r.pageURL(u, page+1)
+	}
+
+	return &links, nil
+}
+
+// offsetPaginationLinks builds the links from the current offset, it can be out of the page grid, like offset=5&limit=10
+func (r *Query) offsetPaginationLinks(base *url.URL, total int64) *PaginationLinks {
+	last := int64(0)
+	if r.Limit > 0 && total > 0 {
+		last = (total - 1) / r.Limit * r.Limit
+	}
+
+	links := PaginationLinks{
+		First: r.offsetURL(base, 0),
+		Last:  r.offsetURL(base, last),
+	}
+
+	if r.Offset > 0 {
+		prev := r.Offset - r.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		if prev > last {
+			prev = last
+		}
+		links.Prev = r.offsetURL(base, prev)
+	}
+
+	if r.Limit > 0 && r.Offset+r.Limit < total {
+		links.Next = r.offsetURL(base, r.Offset+r.Limit)
+	}
+
+	return &links
+}
+
+// CursorPaginationLinks builds the first, prev and next URLs of cursor based lists from baseURL and the cursors
+// returned by the list query, like the id of the last record. The cursor is sent in cursorParam and empty cursors
+// skip the link. Last is always empty, cursor lists don't know the last page
+func (r *Query) CursorPaginationLinks(baseURL, cursorParam, prevCursor, nextCursor string) (*PaginationLinks, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	links := PaginationLinks{
+		First: r.cursorURL(u, cursorParam, ""),
+	}
+
+	if prevCursor != "" {
+		links.Prev = r.cursorURL(u, cursorParam, prevCursor)
+	}
+
+	if nextCursor != "" {
+		links.Next = r.cursorURL(u, cursorParam, nextCursor)
+	}
+
+	return &links, nil
+}
+
+func (r *Query) pageURL(base *url.URL, page int64) string {
+	values := r.linkValues(base)
+	values.Set(r.pageParams()[0], strconv.FormatInt(page, 10))
+
+	u := *base
+	u.RawQuery = values.Encode()
+
+	return u.String()
+}
+
+func (r *Query) offsetURL(base *url.URL, offset int64) string {
+	values := r.linkValues(base)
+	values.Set(r.offsetParams()[0], strconv.FormatInt(offset, 10))
+
+	u := *base
+	u.RawQuery = values.Encode()
+
+	return u.String()
+}
+
+func (r *Query) cursorURL(base *url.URL, cursorParam, cursor string) string {
+	values := r.linkValues(base)
+
+	// the cursor replaces the page and offset params:
+	for _, name := range append(r.pageParams(), r.offsetParams()...) {
+		values.Del(name)
+	}
+	values.Del(cursorParam)
+
+	if cursor != "" {
+		values.Set(cursorParam, cursor)
+	}
+
+	u := *base
+	u.RawQuery = values.Encode()
+
+	return u.String()
+}
+
+// linkValues returns the baseURL params with the query params, the query has priority
+func (r *Query) linkValues(base *url.URL) url.Values {
+	values := base.Query()
+
+	encoded, _ := url.ParseQuery(r.Encode())
+	for key := range encoded {
+		values[key] = encoded[key]
+	}
+
+	return values
+}
@@ -0,0 +1,91 @@
+package query_parser_to_db
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should build all links in a middle page", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/post?title__contains=Hello&limit=10&page=2")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		links, err := q.PaginationLinks("https://example.com/post", 35)
+		assert.Nil(err)
+
+		assert.Equal("https://example.com/post?limit=10&page=1&title__contains=Hello", links.First)
+		assert.Equal("https://example.com/post?limit=10&page=1&title__contains=Hello", links.Prev)
+		assert.Equal("https://example.com/post?limit=10&page=3&title__contains=Hello", links.Next)
+		assert.Equal("https://example.com/post?limit=10&page=4&title__contains=Hello", links.Last)
+	})
+
+	t.Run("Should skip prev in the first page and next in the last page", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/post?limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		links, err := q.PaginationLinks("https://example.com/post?sort=title", 10)
+		assert.Nil(err)
+
+		assert.Equal("https://example.com/post?limit=10&page=1&sort=title", links.First)
+		assert.Equal("", links.Prev)
+		assert.Equal("", links.Next)
+		assert.Equal("https://example.com/post?limit=10&page=1&sort=title", links.Last)
+	})
+
+	t.Run("Should render a Link header", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/post?limit=10&page=2")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		links, err := q.PaginationLinks("/post", 20)
+		assert.Nil(err)
+
+		assert.Equal(`</post?limit=10&page=1>; rel="first", </post?limit=10&page=1>; rel="prev", </post?limit=10&page=2>; rel="last"`, links.LinkHeader())
+	})
+
+	t.Run("Should return an error for invalid base urls", func(t *testing.T) {
+		q := NewQuery(50)
+
+		_, err := q.PaginationLinks("://invalid", 20)
+		assert.NotNil(err)
+	})
+
+	t.Run("Should build cursor links without page params", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/post?title__contains=Hello&limit=10&page=2&cursor=b")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		links, err := q.CursorPaginationLinks("https://example.com/post?sort=id", "cursor", "a", "c")
+		assert.Nil(err)
+
+		assert.Equal("https://example.com/post?limit=10&sort=id&title__contains=Hello", links.First)
+		assert.Equal("https://example.com/post?cursor=a&limit=10&sort=id&title__contains=Hello", links.Prev)
+		assert.Equal("https://example.com/post?cursor=c&limit=10&sort=id&title__contains=Hello", links.Next)
+		assert.Equal("", links.Last)
+	})
+
+	t.Run("Should skip cursor links without cursor", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"limit": {"10"}})
+		assert.Nil(err)
+
+		links, err := q.CursorPaginationLinks("/post", "cursor", "", "")
+		assert.Nil(err)
+
+		assert.Equal(`</post?limit=10>; rel="first"`, links.LinkHeader())
+	})
+}
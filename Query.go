@@ -1,15 +1,50 @@
 package query_parser_to_db
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 const (
 	querySeparator = "__"
+	// page size used when the query has no DefaultLimit
+	defaultLimit    = 10
+	defaultOperator = "equal"
+	// global free-text param, see Query.SearchParam
+	defaultSearchParam = "q"
+	// max number of words in the free-text param, see Query.MaxSearchTerms
+	defaultMaxSearchTerms = 10
+)
+
+// PaginationMode is how the client selected the current page
+type PaginationMode int
+
+const (
+	// page param, the offset is derived from page and limit
+	PaginationModePage PaginationMode = iota
+	// offset param, the page is derived from offset and limit
+	PaginationModeOffset
+)
+
+// ParseMode is how ParseFromURLValues handles invalid limit and page params
+type ParseMode int
+
+const (
+	// invalid limit and page params are ignored or fixed
+	ParseModeLenient ParseMode = iota
+	// invalid limit and page params return errors
+	ParseModeStrict
 )
 
 var (
@@ -19,9 +54,129 @@ var (
 )
 
 type ModelFieldTagConfig struct {
+	// model struct name and field name, like User and Email
+	Model       string
+	FieldName   string
 	Param       string
 	Type        string
 	DBFieldName string
+	// allowed operators, empty for all operators of the field type
+	Operators []string
+	// allowed values, like the enum type choices, empty for all values
+	Values []string
+	// other filter tag keys, like index and columns for the search type
+	Options map[string]string
+	// Search is true if the field is matched by the global free-text param, see Query.SearchParam
+	Search bool
+	// Unsigned is true for unsigned Go integer fields, bigint values are parsed with ParseUnsignedBigint
+	Unsigned bool
+	// other accepted param names, like click_count in param:clickCount|click_count
+	Aliases []string
+	// Deprecated fields still work and are reported to the Query.DeprecationHook
+	Deprecated bool
+	// deprecated param names, like old aliases, reported to the Query.DeprecationHook
+	DeprecatedParams []string
+}
+
+// AllowsOperator returns true if the operator is allowed in this field
+func (r *ModelFieldTagConfig) AllowsOperator(operator string) bool {
+	if len(r.Operators) == 0 {
+		return true
+	}
+
+	for _, op := range r.Operators {
+		if op == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsValue returns true if the value is allowed in this field
+func (r *ModelFieldTagConfig) AllowsValue(value string) bool {
+	if len(r.Values) == 0 {
+		return true
+	}
+
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParamNames returns the param and its aliases
+func (r *ModelFieldTagConfig) ParamNames() []string {
+	return append([]string{r.Param}, r.Aliases...)
+}
+
+// IsDeprecatedParam returns true if the param name is deprecated in this field
+func (r *ModelFieldTagConfig) IsDeprecatedParam(paramName string) bool {
+	if r.Deprecated {
+		return true
+	}
+
+	for _, name := range r.DeprecatedParams {
+		if name == paramName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Column returns the database column of this field, the param if DBFieldName is empty
+func (r *ModelFieldTagConfig) Column() string {
+	if r.DBFieldName == "" {
+		return r.Param
+	}
+
+	return r.DBFieldName
+}
+
+// AvailableOperators returns the operators that can be used in this field, for docs and error messages
+func (r *ModelFieldTagConfig) AvailableOperators() []string {
+	var ops []string
+
+	for op := range GORMDBAdapter[r.Type] {
+		if r.AllowsOperator(op) {
+			ops = append(ops, op)
+		}
+	}
+
+	sort.Strings(ops)
+
+	return ops
+}
+
+// FilterableModel is implemented by models that expose all exported fields as filters, with the json tag names as
+// params. Fields with json:"-" or filter:"-" are not exposed and the filter tags still have priority
+type FilterableModel interface {
+	FilterAllFields() bool
+}
+
+// EnumValuer is implemented by field types with a fixed list of values, used in enum fields without the values tag
+type EnumValuer interface {
+	EnumValues() []string
+}
+
+// FilterPolicy decides if one client filter is allowed, like filters in admin only fields.
+// ctx is the query context, see SetContext
+type FilterPolicy func(ctx context.Context, field *ModelFieldTagConfig, operator string) bool
+
+// DeprecationHook is called for each client filter with one deprecated field or param name, like to log old clients.
+// paramName is the name sent by the client, like click_count
+type DeprecationHook func(ctx context.Context, field *ModelFieldTagConfig, paramName string)
+
+// Scope is one server enforced constraint, like tenant_id = ?, that client params can't override
+type Scope struct {
+	// name used in debug output, like tenant
+	Name  string
+	Query string
+	Args  []interface{}
 }
 
 type QueryAttr struct {
@@ -32,42 +187,159 @@ type QueryAttr struct {
 }
 
 type Query struct {
-	Fields      []QueryAttr
-	Limit       int64
-	LimitMax    int64
-	Page        int64
-	QueryString string
+	Fields []QueryAttr
+	Limit  int64
+	// max page size accepted in the limit param, the default limit if 0
+	LimitMax int64
+	// page size used when the client don't send a valid limit, defaults to 10
+	DefaultLimit int64
+	Page         int64
+	QueryString  string
+	// Strict mode returns errors for invalid limit and page params instead of ignoring them
+	Strict bool
+	// Unpaginated disables the limit and page params and the pagination in database queries, for internal callers
+	Unpaginated bool
+	// Offset is the number of records to skip, used in PaginationModeOffset
+	Offset int64
+	// max offset accepted in the offset param, 0 for no max
+	OffsetMax      int64
+	PaginationMode PaginationMode
+	// reserved param names for limit, page and offset, the first one is used in Encode.
+	// Defaults to limit, page and offset
+	LimitParams  []string
+	PageParams   []string
+	OffsetParams []string
+	// reserved time zone param names, like tz=America/Sao_Paulo, defaults to tz
+	TimezoneParams []string
+	// separator between the param name and operator, defaults to __
+	Separator string
+	// operator used in params without operator, defaults to equal
+	DefaultOperator string
+	// global free-text param matched against the fields with the search flag in the filter tag, defaults to q
+	SearchParam string
+	// max number of words in the search param, defaults to 10. Each word is one LIKE filter in each search field
+	MaxSearchTerms int
+	// server enforced constraints, see AddScope
+	Scopes []Scope
+	// FilterPolicy drops not allowed filters, or returns ErrFilterNotAllowed in strict mode
+	FilterPolicy FilterPolicy
+	// DeprecationHook reports filters with deprecated fields or param names
+	DeprecationHook DeprecationHook
+	// request context used in FilterPolicy, defaults to the database query context
+	Context context.Context
+	// Clock returns the current time used in relative dates like now-7d, defaults to time.Now
+	Clock func() time.Time
+	// Location of relative dates and dates without offset, defaults to UTC
+	Location *time.Location
+	// Limits bounds the client query complexity, checked in ParseFromURLValues
+	Limits QueryLimits
+	// [param]ModelFieldTagConfig of the model in SetDatabaseQueryForModel
+	fieldsConfig map[string]*ModelFieldTagConfig
+	// number of fields matched by the search param in the model, 0 if the search param is not used
+	searchColumns int
 }
 
 func init() {
 	modelSearchTagsCache = make(map[string]map[string]*ModelFieldTagConfig)
 
 	GORMDBAdapter = NewGORMDBAdapter()
+	registerDefaultOperators()
+	registerDefaultValueParsers()
 }
 
 func (r *Query) ParseFromURLValues(query url.Values) error {
-	for key, param := range query {
+	if err := checkOperatorNames(r.separator()); err != nil {
+		return err
+	}
+
+	// sorted keys for a stable filters order:
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	offset := int64(-1)
+
+	for _, key := range keys {
+		param := query[key]
 		// get limit with max value for security:
-		if key == "limit" && len(param) == 1 {
+		if r.isLimitParam(key) {
+			if len(param) != 1 {
+				continue
+			}
+
 			queryLimit, err := strconv.ParseInt(param[0], 10, 64)
 			if err != nil {
 				return ErrInvalidQueryOperator
 			}
-			if queryLimit > 0 && queryLimit < r.LimitMax {
-				r.Limit = queryLimit
+			if r.Strict && (queryLimit < 1 || queryLimit > r.limitMax()) {
+				return fmt.Errorf("%w: %s must be between 1 and %d", ErrInvalidQueryValue, key, r.limitMax())
 			}
+			r.SetLimit(queryLimit)
+			continue
 		}
 		// page for build offset on queries:
-		if key == "page" && len(param) == 1 {
-			page, _ := strconv.ParseInt(param[0], 10, 64)
-			r.Page = page
+		if r.isPageParam(key) {
+			if len(param) != 1 {
+				continue
+			}
+
+			page, err := strconv.ParseInt(param[0], 10, 64)
+			if r.Strict && (err != nil || page < 0) {
+				return fmt.Errorf("%w: %s must be a positive number", ErrInvalidQueryValue, key)
+			}
+			r.SetPage(page)
+			continue
+		}
+		// offset, like page but with the number of records to skip:
+		if r.isOffsetParam(key) {
+			if len(param) != 1 {
+				continue
+			}
+
+			v, err := strconv.ParseInt(param[0], 10, 64)
+			if r.Strict && (err != nil || v < 0) {
+				return fmt.Errorf("%w: %s must be 0 or more", ErrInvalidQueryValue, key)
+			}
+			if r.Strict && r.OffsetMax > 0 && v > r.OffsetMax {
+				return fmt.Errorf("%w: %s must be between 0 and %d", ErrInvalidQueryValue, key, r.OffsetMax)
+			}
+			if err == nil {
+				offset = v
+			}
+			continue
+		}
+
+		// time zone of the date filters:
+		if r.isTimezoneParam(key) {
+			if len(param) != 1 {
+				continue
+			}
+
+			location, err := time.LoadLocation(param[0])
+			if r.Strict && (err != nil || param[0] == "") {
+				return fmt.Errorf("%w: %s must be one IANA time zone, like America/Sao_Paulo", ErrInvalidQueryValue, key)
+			}
+			if err == nil && param[0] != "" {
+				r.SetLocation(location)
+			}
 			continue
 		}
 
 		r.AddQueryParamFromRaw(key, param)
 	}
 
-	return nil
+	// offset has priority over page:
+	if offset >= 0 {
+		r.SetOffset(offset)
+	}
+
+	if r.Limit == 0 {
+		r.SetLimit(0)
+	}
+
+	return r.checkLimits()
 }
 
 func (r *Query) AddQueryParamFromRaw(paramName string, values []string) error {
@@ -75,10 +347,14 @@ func (r *Query) AddQueryParamFromRaw(paramName string, values []string) error {
 		return nil
 	}
 
-	if paramName == "page" {
+	if r.isLimitParam(paramName) || r.isPageParam(paramName) || r.isOffsetParam(paramName) || r.isTimezoneParam(paramName) {
 		return nil
 	}
 
+	if err := checkOperatorNames(r.separator()); err != nil {
+		return err
+	}
+
 	r.AddQueryString(paramName, values)
 
 	var qAttr QueryAttr
@@ -87,19 +363,17 @@ func (r *Query) AddQueryParamFromRaw(paramName string, values []string) error {
 		qAttr.IsMultiple = true
 	}
 
-	for op := range gormDBOperations {
-		if strings.HasSuffix(paramName, querySeparator+op) {
-			qAttr.Values = values
-			qAttr.ParamName = strings.Replace(paramName, querySeparator+op, "", 1)
-			qAttr.Operator = op
-			r.Fields = append(r.Fields, qAttr)
-			return nil
-		}
+	if name, op, ok := parseOperatorSuffix(paramName, r.separator()); ok {
+		qAttr.Values = values
+		qAttr.ParamName = name
+		qAttr.Operator = op
+		r.Fields = append(r.Fields, qAttr)
+		return nil
 	}
 
 	qAttr.Values = values
 	qAttr.ParamName = paramName
-	qAttr.Operator = "equal"
+	qAttr.Operator = r.defaultOperator()
 	r.Fields = append(r.Fields, qAttr)
 	return nil
 }
@@ -110,11 +384,13 @@ func (r *Query) AddQueryString(paramName string, values []string) {
 	}
 
 	if len(values) > 1 {
+		var results []string
 		for i := range values {
-			r.QueryString += paramName + "[]=" + values[i]
+			results = append(results, url.QueryEscape(paramName+"[]")+"="+url.QueryEscape(values[i]))
 		}
+		r.QueryString += strings.Join(results, "&")
 	} else {
-		r.QueryString += paramName + "=" + values[0]
+		r.QueryString += url.QueryEscape(paramName) + "=" + url.QueryEscape(values[0])
 	}
 }
 
@@ -124,11 +400,11 @@ func (r *Query) GetQueryString(paramName string) string {
 			if len(r.Fields[i].Values) == 0 {
 				return ""
 			} else if len(r.Fields[i].Values) == 1 {
-				return paramName + `=` + r.Fields[i].Values[0]
+				return url.QueryEscape(paramName) + `=` + url.QueryEscape(r.Fields[i].Values[0])
 			} else {
 				var results []string
 				for vi := range r.Fields[i].Values {
-					result := paramName + "[]=" + r.Fields[i].Values[vi]
+					result := url.QueryEscape(paramName+"[]") + "=" + url.QueryEscape(r.Fields[i].Values[vi])
 					results = append(results, result)
 				}
 
@@ -140,6 +416,166 @@ func (r *Query) GetQueryString(paramName string) string {
 	return ""
 }
 
+// Encode returns the canonical query string of the parsed filters and pagination, sorted by key and escaped.
+// Operator suffixes are preserved and the result can be parsed back with ParseFromURLValues,
+// use it for pagination links or cache keys
+func (r *Query) Encode() string {
+	values := url.Values{}
+
+	for _, f := range r.Fields {
+		key := f.ParamName
+		if f.Operator != "" && f.Operator != r.defaultOperator() {
+			key += r.separator() + f.Operator
+		}
+
+		values[key] = append(values[key], f.Values...)
+	}
+
+	if r.Limit > 0 {
+		values.Set(r.limitParams()[0], strconv.FormatInt(r.Limit, 10))
+	}
+
+	if r.Location != nil {
+		values.Set(r.timezoneParams()[0], r.Location.String())
+	}
+
+	if r.PaginationMode == PaginationModeOffset {
+		values.Set(r.offsetParams()[0], strconv.FormatInt(r.Offset, 10))
+	} else if r.Page > 0 {
+		values.Set(r.pageParams()[0], strconv.FormatInt(r.Page, 10))
+	}
+
+	return values.Encode()
+}
+
+func (r *Query) limitParams() []string {
+	if len(r.LimitParams) == 0 {
+		return []string{"limit"}
+	}
+
+	return r.LimitParams
+}
+
+func (r *Query) pageParams() []string {
+	if len(r.PageParams) == 0 {
+		return []string{"page"}
+	}
+
+	return r.PageParams
+}
+
+func (r *Query) offsetParams() []string {
+	if len(r.OffsetParams) == 0 {
+		return []string{"offset"}
+	}
+
+	return r.OffsetParams
+}
+
+func (r *Query) isOffsetParam(paramName string) bool {
+	for _, name := range r.offsetParams() {
+		if name == paramName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Query) timezoneParams() []string {
+	if len(r.TimezoneParams) == 0 {
+		return []string{"tz"}
+	}
+
+	return r.TimezoneParams
+}
+
+func (r *Query) isTimezoneParam(paramName string) bool {
+	for _, name := range r.timezoneParams() {
+		if name == paramName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Query) isLimitParam(paramName string) bool {
+	for _, name := range r.limitParams() {
+		if name == paramName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Query) isPageParam(paramName string) bool {
+	for _, name := range r.pageParams() {
+		if name == paramName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Query) separator() string {
+	if r.Separator == "" {
+		return querySeparator
+	}
+
+	return r.Separator
+}
+
+func (r *Query) defaultOperator() string {
+	if r.DefaultOperator == "" {
+		return defaultOperator
+	}
+
+	return r.DefaultOperator
+}
+
+// Now returns the current time of the query clock in the query location
+func (r *Query) Now() time.Time {
+	now := time.Now()
+	if r.Clock != nil {
+		now = r.Clock()
+	}
+
+	return now.In(r.GetLocation())
+}
+
+// SetLocation sets the location of relative dates and day values, like the user time zone
+func (r *Query) SetLocation(location *time.Location) {
+	r.Location = location
+}
+
+// GetLocation returns the location of relative dates and dates without offset
+func (r *Query) GetLocation() *time.Location {
+	if r.Location == nil {
+		return time.UTC
+	}
+
+	return r.Location
+}
+
+func (r *Query) searchParam() string {
+	if r.SearchParam == "" {
+		return defaultSearchParam
+	}
+
+	return r.SearchParam
+}
+
+func (r *Query) maxSearchTerms() int {
+	if r.MaxSearchTerms < 1 {
+		return defaultMaxSearchTerms
+	}
+
+	return r.MaxSearchTerms
+}
+
 func (r *Query) GetParam(paramName string) *QueryAttr {
 	for i := range r.Fields {
 		if r.Fields[i].ParamName == paramName {
@@ -150,6 +586,19 @@ func (r *Query) GetParam(paramName string) *QueryAttr {
 	return nil
 }
 
+// GetParams returns all parsed params with this name, one for each operator
+func (r *Query) GetParams(paramName string) []*QueryAttr {
+	var params []*QueryAttr
+
+	for i := range r.Fields {
+		if r.Fields[i].ParamName == paramName {
+			params = append(params, &r.Fields[i])
+		}
+	}
+
+	return params
+}
+
 func (r *Query) GetParamValue(paramName string) string {
 	for i := range r.Fields {
 		if r.Fields[i].ParamName == paramName {
@@ -166,26 +615,63 @@ func (r *Query) GetLimit() int64 {
 	return r.Limit
 }
 
+// SetLimit sets the page size, values lower than 1 use the default limit and values over LimitMax use LimitMax
 func (r *Query) SetLimit(v int64) {
-	if v > r.LimitMax {
-		r.Limit = r.LimitMax
+	if r.Unpaginated {
+		r.Limit = 0
 		return
 	}
 
-	if v < 0 {
-		r.Limit = 10
-		return
+	if v < 1 {
+		v = r.defaultLimit()
+	}
+
+	if v > r.limitMax() {
+		v = r.limitMax()
 	}
 
 	r.Limit = v
 }
 
+func (r *Query) defaultLimit() int64 {
+	if r.DefaultLimit < 1 {
+		return defaultLimit
+	}
+
+	return r.DefaultLimit
+}
+
+// limitMax returns LimitMax, or the default limit without LimitMax so client limits are always capped
+func (r *Query) limitMax() int64 {
+	if r.LimitMax < 1 {
+		return r.defaultLimit()
+	}
+
+	return r.LimitMax
+}
+
+// GetPage returns the 1-based page param, or the page with the current offset in PaginationModeOffset
 func (r *Query) GetPage() int64 {
+	if r.PaginationMode == PaginationModeOffset {
+		if r.Limit < 1 {
+			return 1
+		}
+
+		return r.Offset/r.Limit + 1
+	}
+
+	if r.Page < 1 {
+		return 1
+	}
+
 	return r.Page
 }
 
 func (r *Query) SetPage(v int64) {
-	if v < 0 {
+	r.PaginationMode = PaginationModePage
+	r.Offset = 0
+
+	if v < 0 || r.Unpaginated {
 		r.Page = 0
 		return
 	}
@@ -193,7 +679,12 @@ func (r *Query) SetPage(v int64) {
 	r.Page = v
 }
 
+// GetOffset returns the offset param in PaginationModeOffset or the offset derived from page and limit
 func (r *Query) GetOffset() int {
+	if r.PaginationMode == PaginationModeOffset {
+		return int(r.Offset)
+	}
+
 	page := int(r.Page)
 
 	if page < 2 {
@@ -204,37 +695,270 @@ func (r *Query) GetOffset() int {
 	return limit * (page - 1)
 }
 
+// SetOffset sets the number of records to skip and changes to PaginationModeOffset.
+// Negative values use 0 and values over OffsetMax use OffsetMax
+func (r *Query) SetOffset(v int64) {
+	if r.Unpaginated {
+		return
+	}
+
+	if v < 0 {
+		v = 0
+	}
+
+	if r.OffsetMax > 0 && v > r.OffsetMax {
+		v = r.OffsetMax
+	}
+
+	r.PaginationMode = PaginationModeOffset
+	r.Offset = v
+	r.Page = 0
+}
+
+// GetPaginationMode returns if the client selected the current page with the page or offset param
+func (r *Query) GetPaginationMode() PaginationMode {
+	return r.PaginationMode
+}
+
+// AddScope adds one server enforced constraint, like AddScope("tenant", "tenant_id = ?", tenantID).
+// Scopes are applied in their own group in SetDatabaseQueryForModel and can't be changed by client params
+func (r *Query) AddScope(name, query string, args ...interface{}) {
+	r.Scopes = append(r.Scopes, Scope{
+		Name:  name,
+		Query: query,
+		Args:  args,
+	})
+}
+
+func (r *Query) GetScopes() []Scope {
+	return r.Scopes
+}
+
+// GetFieldConfig returns the filter tag config of one param in the model used in SetDatabaseQueryForModel,
+// for operators that need extra tag options
+func (r *Query) GetFieldConfig(paramName string) *ModelFieldTagConfig {
+	if cfg := r.fieldsConfig[paramName]; cfg != nil {
+		return cfg
+	}
+
+	// operations receive the column, like created_at for the createdAt param:
+	for _, cfg := range r.fieldsConfig {
+		if cfg.DBFieldName != "" && cfg.DBFieldName == paramName {
+			return cfg
+		}
+		for _, alias := range cfg.Aliases {
+			if alias == paramName {
+				return cfg
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetContext sets the request context used in the FilterPolicy
+func (r *Query) SetContext(ctx context.Context) {
+	r.Context = ctx
+}
+
+func (r *Query) GetContext() context.Context {
+	return r.Context
+}
+
+func (r *Query) SetFilterPolicy(policy FilterPolicy) {
+	r.FilterPolicy = policy
+}
+
+// SetDeprecationHook sets the hook that reports filters with deprecated fields or param names
+func (r *Query) SetDeprecationHook(hook DeprecationHook) {
+	r.DeprecationHook = hook
+}
+
+func (r *Query) policyContext(query interface{}) context.Context {
+	if r.Context != nil {
+		return r.Context
+	}
+
+	if db, ok := query.(*gorm.DB); ok && db.Statement != nil && db.Statement.Context != nil {
+		return db.Statement.Context
+	}
+
+	return context.Background()
+}
+
+// String returns the query filters, pagination and scopes for debug output
+func (r *Query) String() string {
+	var scopes []string
+	for _, scope := range r.Scopes {
+		scopes = append(scopes, fmt.Sprintf("%s(%s %v)", scope.Name, scope.Query, scope.Args))
+	}
+
+	return fmt.Sprintf("query: %s scopes: [%s]", r.Encode(), strings.Join(scopes, ", "))
+}
+
 func (r *Query) SetDatabaseQueryForModel(query interface{}, model interface{}) (interface{}, error) {
+	if db, ok := query.(*gorm.DB); !ok || db == nil {
+		return query, ErrInvalidDBQuery
+	}
+
+	if model == nil {
+		return query, ErrInvalidModel
+	}
+
 	modelType := reflect.TypeOf(model).String()
 
 	if modelSearchTagsCache[modelType] == nil {
 		err := parseAndCacheModel(model)
 		if err != nil {
-			return query, fmt.Errorf("query parser: model parse error: %w", err)
+			return query, err
 		}
 	}
 
+	// scopes first, in their own group, so client filters can only narrow the results:
+	query, err := GORMDBAdapter["scopes"]["group"]("", "", query, r)
+	if err != nil {
+		return query, err
+	}
+
 	if modelSearchTagsCache[modelType] == nil {
 		return query, nil
 	}
 
 	modelCfg := modelSearchTagsCache[modelType]
+	r.fieldsConfig = modelCfg
+	// sorted params for a stable query:
+	params := make([]string, 0, len(modelCfg))
+	for param := range modelCfg {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
 	// each model field:
-	for i := range modelCfg {
-		p := r.GetParam(modelCfg[i].Param)
-		if p == nil {
+	for _, param := range params {
+		// each filter for this field, like price__gte=10&price__lte=20:
+		for _, p := range r.fieldParams(modelCfg[param]) {
+			var err error
+			query, err = r.applyParam(modelCfg[param], p, query)
+			if err != nil {
+				return query, err
+			}
+		}
+	}
+
+	query, err = r.applyFreeTextSearch(modelCfg, params, query)
+	if err != nil {
+		return query, err
+	}
+
+	return GORMDBAdapter["pagination"]["pager"]("", "", query, r)
+}
+
+// applyFreeTextSearch matches the search param against all fields with the search flag.
+// Models with one field named like the search param use that field instead
+func (r *Query) applyFreeTextSearch(modelCfg map[string]*ModelFieldTagConfig, params []string, query interface{}) (interface{}, error) {
+	r.searchColumns = 0
+
+	p := r.GetParam(r.searchParam())
+	if p == nil || modelCfg[r.searchParam()] != nil {
+		return query, nil
+	}
+
+	var columns []string
+	for _, param := range params {
+		cfg := modelCfg[param]
+		if !cfg.Search {
 			continue
 		}
-		var err error
+		// fields hidden by the filter policy are not searched:
+		if r.FilterPolicy != nil && !r.FilterPolicy(r.policyContext(query), cfg, "contains") {
+			continue
+		}
+		columns = append(columns, cfg.Column())
+	}
+
+	if len(columns) == 0 {
+		return query, nil
+	}
+
+	// each word in each column is one LIKE filter, checked with the other filters:
+	r.searchColumns = len(columns)
+	if err := r.checkLimits(); err != nil {
+		return query, err
+	}
+
+	query, err := GORMDBAdapter.Run("freetext", "match", strings.Join(columns, listSeparator), strings.Join(p.Values, " "), query, r)
+	if err != nil {
+		return query, fmt.Errorf("%s: %w", p.ParamName, err)
+	}
+
+	return query, nil
+}
+
+// fieldParams returns the params of one field and its aliases, with the path params of json fields like metadata__path.to.key
+func (r *Query) fieldParams(cfg *ModelFieldTagConfig) []*QueryAttr {
+	var params []*QueryAttr
+	for i := range r.Fields {
+		if _, ok := r.fieldParamName(cfg, r.Fields[i].ParamName); ok {
+			params = append(params, &r.Fields[i])
+		}
+	}
+
+	return params
+}
+
+// fieldParamName returns the field param or alias of one client param name, like metadata for metadata__path.to.key
+func (r *Query) fieldParamName(cfg *ModelFieldTagConfig, paramName string) (string, bool) {
+	for _, name := range cfg.ParamNames() {
+		if paramName == name || (cfg.Type == "json" && strings.HasPrefix(paramName, name+r.separator())) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *Query) applyParam(cfg *ModelFieldTagConfig, p *QueryAttr, query interface{}) (interface{}, error) {
+	// operators of other field types, like title__gt on one string field, are not allowed:
+	op := GetOperator(p.Operator)
+	if !cfg.AllowsOperator(p.Operator) || op == nil || !op.AcceptsType(cfg.Type) {
+		return query, fmt.Errorf("%w: %s accepts %s", ErrOperatorNotAllowed, p.ParamName, strings.Join(cfg.AvailableOperators(), ", "))
+	}
+
+	if r.FilterPolicy != nil && !r.FilterPolicy(r.policyContext(query), cfg, p.Operator) {
+		if r.Strict {
+			return query, fmt.Errorf("%w: %s%s%s", ErrFilterNotAllowed, p.ParamName, r.separator(), p.Operator)
+		}
+		// not allowed filters are ignored in lenient mode:
+		return query, nil
+	}
 
-		query, err = GORMDBAdapter.Run(modelCfg[i].Type, p.Operator, p.ParamName, p.Values[0], query, r)
+	value, err := op.Values(p.Values)
+	if err != nil {
+		return query, fmt.Errorf("%s: %w", p.ParamName, err)
+	}
 
+	if op.Arity != ArityNone {
+		value, err = parseFieldValues(cfg, op, value)
 		if err != nil {
-			return query, fmt.Errorf("query parser: %w", err)
+			return query, err
 		}
 	}
 
-	GORMDBAdapter["pagination"]["pager"]("", "", query, r)
+	name, _ := r.fieldParamName(cfg, p.ParamName)
+	if r.DeprecationHook != nil && cfg.IsDeprecatedParam(name) {
+		r.DeprecationHook(r.policyContext(query), cfg, name)
+	}
+
+	column := cfg.Column()
+	// json paths are sent to the operations as column.path.to.key:
+	if path := strings.TrimPrefix(p.ParamName, name+r.separator()); path != p.ParamName {
+		column += "." + path
+	}
+
+	query, err = GORMDBAdapter.Run(cfg.Type, p.Operator, column, value, query, r)
+	if err != nil {
+		return query, fmt.Errorf("%s: %w", p.ParamName, err)
+	}
 
 	return query, nil
 }
@@ -243,23 +967,77 @@ func parseAndCacheModel(model interface{}) error {
 	modelCfg := make(map[string]*ModelFieldTagConfig)
 	modelType := reflect.TypeOf(model).String()
 
+	if reflect.TypeOf(model).Kind() != reflect.Ptr || reflect.TypeOf(model).Elem().Kind() != reflect.Struct {
+		return ErrInvalidModel
+	}
+
+	m, ok := model.(FilterableModel)
+	filterAll := ok && m.FilterAllFields()
+
 	ut := reflect.TypeOf(model).Elem()
+	if err := parseModelFields(ut, ut.Name(), filterAll, modelCfg); err != nil {
+		return err
+	}
+
+	modelSearchTagsCache[modelType] = modelCfg
+
+	return nil
+}
+
+// parseModelFields adds the filter config of each ut field in modelCfg.
+// With filterAll, fields without filter tag use the json tag name as param, embedded structs like gorm.Model included
+func parseModelFields(ut reflect.Type, modelName string, filterAll bool, modelCfg map[string]*ModelFieldTagConfig) error {
 	for i := 0; i < ut.NumField(); i++ {
 		field := ut.Field(i)
 
-		if filterConfig, ok := field.Tag.Lookup("filter"); ok {
+		filterConfig, ok := field.Tag.Lookup("filter")
+		if !ok && filterAll {
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+
+			if t := field.Type; field.Anonymous && (t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct)) {
+				if t.Kind() == reflect.Ptr {
+					t = t.Elem()
+				}
+				if err := parseModelFields(t, modelName, filterAll, modelCfg); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// associations, like Author Author or Comments []Comment, are not columns:
+			if !isColumnType(field.Type) {
+				continue
+			}
+
+			ok = true
+		}
+
+		if ok {
 			if filterConfig == "-" {
 				continue
 			}
 
 			cfg := ModelFieldTagConfig{
+				Model:     modelName,
+				FieldName: field.Name,
 				// default name is the struct field name:
 				Param: field.Name,
-				Type:  "default",
+				// the type tag has priority:
+				Type:     inferFieldType(field.Type),
+				Unsigned: isUnsignedType(field.Type),
+			}
+
+			if filterAll {
+				cfg.Param = jsonFieldName(field)
+				cfg.DBFieldName = gormColumnName(field)
 			}
 
 			if filterConfig == "" {
-				modelCfg[cfg.Param] = &cfg
+				if err := addFieldConfig(modelCfg, &cfg); err != nil {
+					return err
+				}
 				continue
 			}
 
@@ -267,22 +1045,200 @@ func parseAndCacheModel(model interface{}) error {
 			tagDataLine := strings.Split(rawTagData, ";")
 
 			for _, v := range tagDataLine {
+				// flags without value, like search:
+				switch strings.TrimSpace(v) {
+				case "search":
+					cfg.Search = true
+					continue
+				case "deprecated":
+					cfg.Deprecated = true
+					continue
+				}
+
 				tagData := strings.Split(v, ":")
+				if len(tagData) != 2 {
+					continue
+				}
 
-				if tagData[0] == "param" && tagData[1] != "" {
-					cfg.Param = tagData[1]
+				if tagData[1] == "" {
+					continue
 				}
 
-				if tagData[0] == "type" && tagData[1] != "" {
+				switch tagData[0] {
+				case "param":
+					// the first name is the canonical one, like clickCount in clickCount|click_count:
+					names := strings.Split(tagData[1], "|")
+					cfg.Param = strings.TrimSpace(names[0])
+					for _, alias := range names[1:] {
+						cfg.Aliases = append(cfg.Aliases, strings.TrimSpace(alias))
+					}
+				case "deprecated":
+					for _, name := range strings.Split(tagData[1], ",") {
+						cfg.DeprecatedParams = append(cfg.DeprecatedParams, strings.TrimSpace(name))
+					}
+				case "type":
 					cfg.Type = tagData[1]
+				case "values":
+					for _, v := range strings.Split(tagData[1], ",") {
+						cfg.Values = append(cfg.Values, strings.TrimSpace(v))
+					}
+				case "ops":
+					for _, op := range strings.Split(tagData[1], ",") {
+						op = strings.TrimSpace(op)
+						if GetOperator(op) == nil {
+							return fmt.Errorf("%w: %s in %s.%s", ErrInvalidQueryOperator, op, modelName, field.Name)
+						}
+						cfg.Operators = append(cfg.Operators, op)
+					}
+				default:
+					// other keys are used by operators, like index in the search type:
+					if cfg.Options == nil {
+						cfg.Options = make(map[string]string)
+					}
+					cfg.Options[tagData[0]] = tagData[1]
 				}
 			}
 
-			modelCfg[cfg.Param] = &cfg
+			if cfg.Type == "enum" && len(cfg.Values) == 0 {
+				cfg.Values = enumValues(field.Type)
+				if len(cfg.Values) == 0 {
+					return fmt.Errorf("%w: %s.%s", ErrInvalidEnumConfig, modelName, field.Name)
+				}
+			}
+
+			if err := addFieldConfig(modelCfg, &cfg); err != nil {
+				return err
+			}
 		}
 	}
 
-	modelSearchTagsCache[modelType] = modelCfg
+	return nil
+}
+
+// addFieldConfig adds the field config in modelCfg, or returns one error if its param or aliases are used in other field
+func addFieldConfig(modelCfg map[string]*ModelFieldTagConfig, cfg *ModelFieldTagConfig) error {
+	for _, other := range modelCfg {
+		for _, name := range cfg.ParamNames() {
+			for _, otherName := range other.ParamNames() {
+				if name == otherName {
+					return fmt.Errorf("%w: %s in %s.%s and %s.%s", ErrDuplicatedParam, name, other.Model, other.FieldName, cfg.Model, cfg.FieldName)
+				}
+			}
+		}
+	}
+
+	modelCfg[cfg.Param] = cfg
+
+	return nil
+}
+
+// jsonFieldName returns the json tag name of the field, or the field name without json name
+func jsonFieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+
+	return field.Name
+}
+
+// gormColumnName returns the column in the gorm tag, or the column of the default GORM naming strategy
+func gormColumnName(field reflect.StructField) string {
+	for _, v := range strings.Split(field.Tag.Get("gorm"), ";") {
+		tagData := strings.SplitN(v, ":", 2)
+		if len(tagData) == 2 && strings.EqualFold(strings.TrimSpace(tagData[0]), "column") {
+			return strings.TrimSpace(tagData[1])
+		}
+	}
+
+	return schema.NamingStrategy{}.ColumnName("", field.Name)
+}
+
+// inferFieldType returns the filter type of one Go field type, like number for int64 or time for time.Time.
+// Pointers use the type they point to. Unknown types are default, with the equal and null operators
+func inferFieldType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if enumValues(t) != nil {
+		return "enum"
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(gorm.DeletedAt{}):
+		return "time"
+	case reflect.TypeOf(sql.NullString{}):
+		return "string"
+	case reflect.TypeOf(sql.NullBool{}):
+		return "bool"
+	case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}), reflect.TypeOf(sql.NullInt16{}),
+		reflect.TypeOf(sql.NullByte{}), reflect.TypeOf(sql.NullFloat64{}):
+		return "number"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "default"
+	}
+}
+
+// isColumnType returns true for field types stored in one column, like scalars, time.Time, []byte
+// and driver.Valuer types like sql.NullString or gorm.DeletedAt. Other structs, slices and maps are associations
+func isColumnType(t reflect.Type) bool {
+	valuer := reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Implements(valuer) || reflect.PtrTo(t).Implements(valuer) || t == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
+		return false
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
+	default:
+		return true
+	}
+}
+
+func isUnsignedType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// enumValues returns the values of field types that implement EnumValuer, with value or pointer receivers
+func enumValues(t reflect.Type) []string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if v, ok := reflect.Zero(t).Interface().(EnumValuer); ok {
+		return v.EnumValues()
+	}
+
+	if v, ok := reflect.New(t).Interface().(EnumValuer); ok {
+		return v.EnumValues()
+	}
 
 	return nil
 }
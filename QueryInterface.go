@@ -1,14 +1,22 @@
 package query_parser_to_db
 
-import "net/url"
+import (
+	"context"
+	"net/url"
+	"time"
+)
 
 type QueryInterface interface {
 	ParseFromURLValues(query url.Values) error
 	AddQueryParamFromRaw(paramName string, values []string) error
 	AddQueryString(paramName string, values []string)
 	GetQueryString(paramName string) string
+	// Get the canonical query string, sorted and escaped
+	Encode() string
 	GetParamValue(paramName string) string
 	GetParam(paramName string) *QueryAttr
+	// Get all params with this name, one for each operator
+	GetParams(paramName string) []*QueryAttr
 	// Get limit query param
 	GetLimit() int64
 	SetLimit(v int64)
@@ -16,5 +24,34 @@ type QueryInterface interface {
 	GetPage() int64
 	SetPage(v int64)
 	GetOffset() int
+	// Set offset, for offset based pagination
+	SetOffset(v int64)
+	// Get if the page was selected with the page or offset param
+	GetPaginationMode() PaginationMode
+	// Get the first, prev, next and last page URLs for this query
+	PaginationLinks(baseURL string, total int64) (*PaginationLinks, error)
+	// Get the first, prev and next URLs of cursor based lists
+	CursorPaginationLinks(baseURL, cursorParam, prevCursor, nextCursor string) (*PaginationLinks, error)
+	// Add one server enforced constraint, applied in its own group
+	AddScope(name, query string, args ...interface{})
+	GetScopes() []Scope
+	// Get the filter tag config of one param in the current model
+	GetFieldConfig(paramName string) *ModelFieldTagConfig
+	// Set the request context used in the filter policy
+	SetContext(ctx context.Context)
+	GetContext() context.Context
+	// Set the policy that decides if one client filter is allowed
+	SetFilterPolicy(policy FilterPolicy)
+	// Set the hook that reports filters with deprecated fields or param names
+	SetDeprecationHook(hook DeprecationHook)
+	// Get the current time used in relative dates, in the query location
+	Now() time.Time
+	// Set the location of relative dates and day values, like the user time zone
+	SetLocation(location *time.Location)
+	GetLocation() *time.Location
+	// Get the query complexity score
+	Complexity() int
+	// Get the filters, pagination and scopes for debug output
+	String() string
 	SetDatabaseQueryForModel(query interface{}, model interface{}) (interface{}, error)
 }
@@ -1,8 +1,11 @@
 package query_parser_to_db
 
 import (
+	"context"
+	"database/sql"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/stretchr/testify/assert"
@@ -26,8 +29,19 @@ func GetFakeGormDB() *gorm.DB {
 	return db
 }
 
+// applyRawQuery parses rawQuery in a new query and applies it in db for the model T
+func applyRawQuery[T any](t *testing.T, rawQuery string, db *gorm.DB) (*gorm.DB, error) {
+	values, err := url.ParseQuery(rawQuery)
+	assert.Nil(t, err)
+
+	q := NewQuery(50)
+	assert.Nil(t, q.ParseFromURLValues(values))
+
+	return ApplyGORM[T](q, db)
+}
+
 type ContentModelStub struct {
-	ID         uint64 `json:"id" filter:"param:id;type:string"`
+	ID         uint64 `json:"id" filter:"param:id;type:bigint"`
 	Title      string `json:"title" filter:"param:title;type:string"`
 	Body       string `json:"body" filter:"type:string"`
 	Published  bool   `json:"published" filter:"param:published;type:bool"`
@@ -64,7 +78,7 @@ func TestQueryParser(t *testing.T) {
 	assert.Nil(err)
 
 	t.Run("Should parse and load data from valid url query params", func(t *testing.T) {
-		urlString := "https://example.com/example?content_contains=He&id=10&limit=3&page=2"
+		urlString := "https://example.com/example?content__contains=He&id=10&limit=3&page=2"
 		parsedURL, _ := url.Parse(urlString)
 
 		// rawParamName
@@ -78,7 +92,7 @@ func TestQueryParser(t *testing.T) {
 		assert.Equal("He", q.GetParamValue("content"))
 	})
 	t.Run("Should parse and generate a DryRun GORM sql", func(t *testing.T) {
-		urlString := "https://example.com/example?title_contains=Hello&limit=5&page=3"
+		urlString := "https://example.com/example?title__contains=Hello&limit=5&page=3"
 		parsedURL, _ := url.Parse(urlString)
 		// rawParamName
 		q := NewQuery(50)
@@ -101,7 +115,7 @@ func TestQueryParser(t *testing.T) {
 		query.DryRun = false
 	})
 	t.Run("Should parse and run a valid sql query", func(t *testing.T) {
-		urlString := "https://example.com/example?title_contains=Hello&limit=3"
+		urlString := "https://example.com/example?title__contains=Hello&limit=3"
 		parsedURL, _ := url.Parse(urlString)
 
 		// rawParamName
@@ -142,7 +156,7 @@ func TestQueryParserGetSetMethods(t *testing.T) {
 	assert := assert.New(t)
 
 	t.Run("Should set and get Limit", func(t *testing.T) {
-		urlString := "https://example.com/example?title_contains=Hello&limit=3"
+		urlString := "https://example.com/example?title__contains=Hello&limit=3"
 		parsedURL, _ := url.Parse(urlString)
 
 		// rawParamName
@@ -163,7 +177,7 @@ func TestQueryParserGetSetMethods(t *testing.T) {
 	})
 
 	t.Run("Should set and get page", func(t *testing.T) {
-		urlString := "https://example.com/example?title_contains=Hello&limit=10&page=2"
+		urlString := "https://example.com/example?title__contains=Hello&limit=10&page=2"
 		parsedURL, _ := url.Parse(urlString)
 
 		// rawParamName
@@ -174,8 +188,787 @@ func TestQueryParserGetSetMethods(t *testing.T) {
 		assert.Equal(int64(2), q.GetPage())
 		q.SetPage(5)
 		assert.Equal(int64(5), q.GetPage())
-		// should not be negative
+		// should not be less than the first page
 		q.SetPage(-10)
-		assert.Equal(int64(0), q.GetPage())
+		assert.Equal(int64(1), q.GetPage())
+	})
+}
+
+type OperatorsModelStub struct {
+	ID    uint64 `json:"id"`
+	Email string `json:"email" filter:"param:email;type:string;ops:equal,is-null"`
+}
+
+type InvalidOperatorsModelStub struct {
+	Email string `json:"email" filter:"param:email;type:string;ops:equal,unknown"`
+}
+
+func TestQueryParserOperatorsAllowList(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should apply allowed operators", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?email=a@example.com&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[OperatorsModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]OperatorsModelStub{})
+		assert.Equal("SELECT * FROM `operators_model_stubs` WHERE email = ? LIMIT 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should return an error for operators not in the allow list", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?email__contains=example&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		_, err = ApplyGORM[OperatorsModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrOperatorNotAllowed)
+		assert.Contains(err.Error(), "email accepts equal, is-null")
+	})
+
+	t.Run("Should return an error for operators of other field types", func(t *testing.T) {
+		for _, rawQuery := range []string{"title__gt=5", "clickCount__contains=5", "title__has=x"} {
+			parsedURL, _ := url.Parse("https://example.com/example?" + rawQuery)
+
+			q := NewQuery(50)
+			err := q.ParseFromURLValues(parsedURL.Query())
+			assert.Nil(err)
+
+			_, err = ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+			assert.ErrorIs(err, ErrOperatorNotAllowed, rawQuery)
+		}
+	})
+
+	t.Run("Should return an error for unknown operators in the filter tag", func(t *testing.T) {
+		q := NewQuery(50)
+
+		_, err := ApplyGORM[InvalidOperatorsModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidQueryOperator)
+	})
+}
+
+func TestQueryParserMultipleFiltersInSameParam(t *testing.T) {
+	assert := assert.New(t)
+
+	urlString := "https://example.com/example?clickCount__gte=5&clickCount__lte=10&title__contains=Hello&limit=10"
+	parsedURL, _ := url.Parse(urlString)
+
+	q := NewQuery(50)
+	err := q.ParseFromURLValues(parsedURL.Query())
+	assert.Nil(err)
+
+	t.Run("Should return all params with the same name", func(t *testing.T) {
+		params := q.GetParams("clickCount")
+		assert.Equal(2, len(params))
+		assert.Equal("gte", params[0].Operator)
+		assert.Equal([]string{"5"}, params[0].Values)
+		assert.Equal("lte", params[1].Operator)
+		assert.Equal([]string{"10"}, params[1].Values)
+
+		assert.Equal(0, len(q.GetParams("body")))
+	})
+
+	t.Run("Should apply all filters of the same param", func(t *testing.T) {
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Nil(r.Error)
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE clickCount >= ? AND clickCount <= ? AND title LIKE ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"5", "10", "%Hello%"}, r.Statement.Vars)
+	})
+}
+
+func TestQueryParserEncode(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should encode sorted and escaped params with operator suffixes", func(t *testing.T) {
+		values := url.Values{
+			"title__contains": {"a&b=c"},
+			"clickCount__gte": {"5"},
+			"body":            {"x y"},
+			"id":              {"1", "2"},
+			"limit":           {"10"},
+			"page":            {"2"},
+		}
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(values)
+		assert.Nil(err)
+
+		assert.Equal("body=x+y&clickCount__gte=5&id=1&id=2&limit=10&page=2&title__contains=a%26b%3Dc", q.Encode())
+	})
+
+	t.Run("Should round trip through ParseFromURLValues", func(t *testing.T) {
+		urlString := "https://example.com/example?title__contains=He%26llo&title__not-contains=Bye&published=true&limit=5&page=3"
+		parsedURL, _ := url.Parse(urlString)
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		encoded, err := url.ParseQuery(q.Encode())
+		assert.Nil(err)
+
+		q2 := NewQuery(50)
+		err = q2.ParseFromURLValues(encoded)
+		assert.Nil(err)
+
+		assert.Equal(q.Encode(), q2.Encode())
+		assert.Equal(q.GetLimit(), q2.GetLimit())
+		assert.Equal(q.GetPage(), q2.GetPage())
+		assert.Equal("He&llo", q2.GetParamValue("title"))
+	})
+
+	t.Run("Should escape and separate multiple values in QueryString", func(t *testing.T) {
+		q := &Query{LimitMax: 50}
+		q.AddQueryString("id", []string{"1", "2&3"})
+		q.AddQueryString("title", []string{"a b"})
+
+		assert.Equal("id%5B%5D=1&id%5B%5D=2%263&title=a+b", q.QueryString)
+	})
+}
+
+func TestQueryParserLimitDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should use the default limit if the client don't send one", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{})
+		assert.Nil(err)
+		assert.Equal(int64(10), q.GetLimit())
+
+		q = NewQuery(50, WithDefaultLimit(25))
+		err = q.ParseFromURLValues(url.Values{"limit": {"0"}})
+		assert.Nil(err)
+		assert.Equal(int64(25), q.GetLimit())
+
+		q = NewQuery(5)
+		assert.Equal(int64(5), q.GetLimit())
+	})
+
+	t.Run("Should set the limit max with the options", func(t *testing.T) {
+		q := NewQuery(0, WithLimitMax(100))
+		err := q.ParseFromURLValues(url.Values{"limit": {"150"}})
+		assert.Nil(err)
+		assert.Equal(int64(100), q.GetLimit())
+	})
+
+	t.Run("Should accept a limit equal to LimitMax and clamp bigger values like SetLimit", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"limit": {"50"}})
+		assert.Nil(err)
+		assert.Equal(int64(50), q.GetLimit())
+
+		q = NewQuery(50)
+		err = q.ParseFromURLValues(url.Values{"limit": {"51"}})
+		assert.Nil(err)
+		assert.Equal(int64(50), q.GetLimit())
+
+		q = &Query{LimitMax: 50, Strict: true}
+		err = q.ParseFromURLValues(url.Values{"limit": {"51"}})
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+	})
+
+	t.Run("Should cap client limits with the default limit without LimitMax", func(t *testing.T) {
+		q := NewQuery(0)
+		err := q.ParseFromURLValues(url.Values{"limit": {"100000000"}})
+		assert.Nil(err)
+		assert.Equal(int64(10), q.GetLimit())
+
+		q = NewQuery(0, WithDefaultLimit(25))
+		q.SetLimit(100000000)
+		assert.Equal(int64(25), q.GetLimit())
+
+		q, err = MiddlewareConfig{}.ParseQuery(url.Values{"limit": {"100000000"}})
+		assert.Nil(err)
+		assert.Equal(int64(10), q.GetLimit())
+	})
+
+	t.Run("Should not paginate without pagination", func(t *testing.T) {
+		q := NewQuery(50, WithoutPagination())
+		err := q.ParseFromURLValues(url.Values{"limit": {"5"}, "page": {"3"}})
+		assert.Nil(err)
+		assert.Equal(int64(0), q.GetLimit())
+		assert.Equal(int64(1), q.GetPage())
+		assert.Equal(0, q.GetOffset())
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs`", r.Statement.SQL.String())
+	})
+}
+
+type PageModelStub struct {
+	ID   uint64 `json:"id"`
+	Page string `json:"page" filter:"param:page;type:string"`
+}
+
+func TestQueryParserOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should use custom reserved limit and page params", func(t *testing.T) {
+		q := NewQuery(50, WithLimitParams("limit", "per_page"), WithPageParams("p"))
+		err := q.ParseFromURLValues(url.Values{
+			"per_page":          {"5"},
+			"p":                 {"3"},
+			"page__starts-with": {"intro"},
+		})
+		assert.Nil(err)
+
+		assert.Equal(int64(5), q.GetLimit())
+		assert.Equal(int64(3), q.GetPage())
+		assert.Nil(q.GetParam("per_page"))
+		assert.Equal("intro", q.GetParamValue("page"))
+		assert.Equal("limit=5&p=3&page__starts-with=intro", q.Encode())
+
+		query, err := ApplyGORM[PageModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]PageModelStub{})
+		assert.Equal("SELECT * FROM `page_model_stubs` WHERE page LIKE ? LIMIT 5 OFFSET 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should use a custom separator and default operator", func(t *testing.T) {
+		q := NewQuery(50, WithSeparator("."), WithDefaultOperator("contains"))
+		err := q.ParseFromURLValues(url.Values{
+			"title":          {"Hello"},
+			"body.not-equal": {"Bye"},
+		})
+		assert.Nil(err)
+
+		assert.Equal("contains", q.GetParam("title").Operator)
+		assert.Equal("not-equal", q.GetParam("body").Operator)
+		assert.Equal("body.not-equal=Bye&limit=10&title=Hello", q.Encode())
+	})
+
+	t.Run("Should return errors for invalid params in strict parse mode", func(t *testing.T) {
+		q := NewQuery(50, WithParseMode(ParseModeStrict))
+		err := q.ParseFromURLValues(url.Values{"page": {"abc"}})
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+
+		q = NewQuery(50, WithParseMode(ParseModeLenient))
+		err = q.ParseFromURLValues(url.Values{"page": {"abc"}})
+		assert.Nil(err)
+		assert.Equal(int64(1), q.GetPage())
+	})
+}
+
+func TestQueryParserOffsetPagination(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should use the offset param", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"offset": {"20"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		assert.Equal(PaginationModeOffset, q.GetPaginationMode())
+		assert.Equal(20, q.GetOffset())
+		assert.Equal(int64(3), q.GetPage())
+		assert.Equal("limit=10&offset=20", q.Encode())
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs` LIMIT 10 OFFSET 20", r.Statement.SQL.String())
+	})
+
+	t.Run("Should prefer offset over page and keep page mode without offset", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"offset": {"5"}, "page": {"4"}, "limit": {"10"}})
+		assert.Nil(err)
+		assert.Equal(PaginationModeOffset, q.GetPaginationMode())
+		assert.Equal(5, q.GetOffset())
+
+		q = NewQuery(50)
+		err = q.ParseFromURLValues(url.Values{"page": {"4"}, "limit": {"10"}})
+		assert.Nil(err)
+		assert.Equal(PaginationModePage, q.GetPaginationMode())
+		assert.Equal(30, q.GetOffset())
+	})
+
+	t.Run("Should protect the max offset", func(t *testing.T) {
+		q := NewQuery(50, WithOffsetMax(1000))
+		err := q.ParseFromURLValues(url.Values{"offset": {"5000"}})
+		assert.Nil(err)
+		assert.Equal(1000, q.GetOffset())
+
+		q = NewQuery(50, WithOffsetMax(1000), WithParseMode(ParseModeStrict))
+		err = q.ParseFromURLValues(url.Values{"offset": {"5000"}})
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+		assert.Equal("query parser: invalid query value: offset must be between 0 and 1000", err.Error())
+
+		q = NewQuery(50, WithParseMode(ParseModeStrict))
+		err = q.ParseFromURLValues(url.Values{"offset": {"-5"}})
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+		assert.Equal("query parser: invalid query value: offset must be 0 or more", err.Error())
+	})
+
+	t.Run("Should build pagination links with offsets", func(t *testing.T) {
+		q := NewQuery(50, WithOffsetParams("skip"))
+		err := q.ParseFromURLValues(url.Values{"skip": {"10"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		links, err := q.PaginationLinks("/post", 25)
+		assert.Nil(err)
+		assert.Equal("/post?limit=10&skip=0", links.Prev)
+		assert.Equal("/post?limit=10&skip=20", links.Next)
+	})
+
+	t.Run("Should build pagination links from offsets out of the page grid", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"offset": {"5"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		links, err := q.PaginationLinks("/post", 100)
+		assert.Nil(err)
+		assert.Equal("/post?limit=10&offset=0", links.First)
+		assert.Equal("/post?limit=10&offset=0", links.Prev)
+		assert.Equal("/post?limit=10&offset=15", links.Next)
+		assert.Equal("/post?limit=10&offset=90", links.Last)
+
+		q = NewQuery(50)
+		err = q.ParseFromURLValues(url.Values{"offset": {"95"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		links, err = q.PaginationLinks("/post", 100)
+		assert.Nil(err)
+		assert.Equal("/post?limit=10&offset=85", links.Prev)
+		assert.Equal("", links.Next)
+		assert.Equal("/post?limit=10&offset=90", links.Last)
+	})
+
+	t.Run("Should skip prev in the first offset and build links without records", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"offset": {"0"}, "limit": {"10"}})
+		assert.Nil(err)
+
+		links, err := q.PaginationLinks("/post", 0)
+		assert.Nil(err)
+		assert.Equal("/post?limit=10&offset=0", links.First)
+		assert.Equal("", links.Prev)
+		assert.Equal("", links.Next)
+		assert.Equal("/post?limit=10&offset=0", links.Last)
+	})
+}
+
+func TestQueryParserScopes(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should apply scopes in their own group before client filters", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?title__contains=Hello&clickCount=1&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		q.AddScope("tenant", "tenant_id = ?", 7)
+		q.AddScope("owner", "owner_id = ? OR public = ?", 3, true)
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE (tenant_id = ? AND (owner_id = ? OR public = ?)) AND clickCount = ? AND title LIKE ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{7, 3, true, "1", "%Hello%"}, r.Statement.Vars)
+	})
+
+	t.Run("Should show scopes in debug output", func(t *testing.T) {
+		q := NewQuery(50)
+		q.AddScope("tenant", "tenant_id = ?", 7)
+
+		assert.Equal("query: limit=10 scopes: [tenant(tenant_id = ? [7])]", q.String())
+	})
+}
+
+type adminContextKey struct{}
+
+func TestQueryParserFilterPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	// Email2 filters are admin only and id accepts only equal:
+	policy := func(ctx context.Context, field *ModelFieldTagConfig, operator string) bool {
+		if field.Model == "ContentModelStub" && field.FieldName == "Email2" {
+			return ctx.Value(adminContextKey{}) == true
+		}
+		if field.Param == "id" {
+			return operator == "equal"
+		}
+		return true
+	}
+
+	values := url.Values{"Email2": {"a@example.com"}, "title": {"Hello"}, "id__not-equal": {"1"}, "limit": {"10"}}
+
+	t.Run("Should drop not allowed filters in lenient mode", func(t *testing.T) {
+		q := NewQuery(50, WithFilterPolicy(policy))
+		err := q.ParseFromURLValues(values)
+		assert.Nil(err)
+
+		query, err := ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE title = ? LIMIT 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should allow filters with the request context", func(t *testing.T) {
+		q := NewQuery(50, WithFilterPolicy(policy))
+		err := q.ParseFromURLValues(values)
+		assert.Nil(err)
+
+		ctx := context.WithValue(context.Background(), adminContextKey{}, true)
+		db := GetFakeGormDB().Session(&gorm.Session{DryRun: true}).WithContext(ctx)
+
+		query, err := ApplyGORM[ContentModelStub](q, db)
+		assert.Nil(err)
+
+		r := query.Find(&[]ContentModelStub{})
+		assert.Equal("SELECT * FROM `content_model_stubs` WHERE Email2 = ? AND title = ? LIMIT 10", r.Statement.SQL.String())
+	})
+
+	t.Run("Should return an error for not allowed filters in strict mode", func(t *testing.T) {
+		q := NewQuery(50, WithFilterPolicy(policy), WithParseMode(ParseModeStrict))
+		err := q.ParseFromURLValues(values)
+		assert.Nil(err)
+		q.SetContext(context.WithValue(context.Background(), adminContextKey{}, true))
+
+		_, err = ApplyGORM[ContentModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrFilterNotAllowed)
+		assert.Contains(err.Error(), "id__not-equal")
+	})
+}
+
+func TestQueryParserTimezone(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should set the location from the tz param", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"tz": {"America/Sao_Paulo"}, "title": {"a"}})
+		assert.Nil(err)
+
+		assert.Equal("America/Sao_Paulo", q.GetLocation().String())
+		assert.Nil(q.GetParam("tz"))
+		assert.Equal("limit=10&title=a&tz=America%2FSao_Paulo", q.Encode())
+	})
+
+	t.Run("Should ignore invalid time zones or return errors in strict mode", func(t *testing.T) {
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(url.Values{"tz": {"Mars/Olympus"}})
+		assert.Nil(err)
+		assert.Equal(time.UTC, q.GetLocation())
+
+		q = NewQuery(50, WithParseMode(ParseModeStrict), WithTimezoneParams("timezone"))
+		err = q.ParseFromURLValues(url.Values{"timezone": {"Mars/Olympus"}})
+		assert.ErrorIs(err, ErrInvalidQueryValue)
+	})
+}
+
+type PostStatusStub string
+
+func (PostStatusStub) EnumValues() []string {
+	return []string{"draft", "published"}
+}
+
+type EnumModelStub struct {
+	ID         uint64         `json:"id"`
+	Status     string         `json:"status" filter:"param:status;type:enum;values:draft,published,archived"`
+	Visibility PostStatusStub `json:"visibility" filter:"param:visibility;type:enum"`
+}
+
+type InvalidEnumModelStub struct {
+	ID     uint64 `json:"id"`
+	Status string `json:"status" filter:"param:status;type:enum"`
+}
+
+func TestQueryParserEnum(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should filter with allowed enum values", func(t *testing.T) {
+		parsedURL, _ := url.Parse("https://example.com/example?status__in=draft,archived&visibility=published&limit=10")
+
+		q := NewQuery(50)
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[EnumModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]EnumModelStub{})
+		assert.Equal("SELECT * FROM `enum_model_stubs` WHERE status IN (?,?) AND visibility = ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]interface{}{"draft", "archived", "published"}, r.Statement.Vars)
+		assert.Equal([]string{"draft", "published"}, q.GetFieldConfig("visibility").Values)
+	})
+
+	t.Run("Should reject values not in the enum", func(t *testing.T) {
+		for _, rawQuery := range []string{"status=deleted", "status__not-in=draft,deleted", "visibility=archived"} {
+			values, _ := url.ParseQuery(rawQuery)
+
+			q := NewQuery(50)
+			assert.Nil(q.ParseFromURLValues(values))
+
+			_, err := ApplyGORM[EnumModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+			assert.ErrorIs(err, ErrInvalidQueryValue, rawQuery)
+		}
+	})
+
+	t.Run("Should return an error for enums without values", func(t *testing.T) {
+		_, err := ApplyGORM[InvalidEnumModelStub](NewQuery(50), GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.ErrorIs(err, ErrInvalidEnumConfig)
+	})
+}
+
+type InferredTypesModelStub struct {
+	ID          uint64         `json:"id" filter:""`
+	Title       string         `json:"title" filter:"param:title"`
+	Views       *int32         `json:"views" filter:"param:views"`
+	Score       float64        `json:"score" filter:""`
+	Published   bool           `json:"published" filter:""`
+	PublishedAt *time.Time     `json:"publishedAt" filter:"param:published_at"`
+	Subtitle    sql.NullString `json:"subtitle" filter:""`
+	Rank        sql.NullInt64  `json:"rank" filter:""`
+	Featured    sql.NullBool   `json:"featured" filter:""`
+	DeletedAt   gorm.DeletedAt `json:"deletedAt" filter:"param:deleted_at"`
+	Visibility  PostStatusStub `json:"visibility" filter:""`
+	Data        []byte         `json:"data" filter:""`
+	Slug        string         `json:"slug" filter:"param:slug;type:default"`
+}
+
+func TestQueryParserInferFieldType(t *testing.T) {
+	assert := assert.New(t)
+
+	q := NewQuery(50)
+	assert.Nil(q.ParseFromURLValues(url.Values{"views__gte": {"10"}, "published_at__gte": {"2024-03-01T00:00:00Z"}, "limit": {"10"}}))
+
+	query, err := ApplyGORM[InferredTypesModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+	assert.Nil(err)
+
+	r := query.Find(&[]InferredTypesModelStub{})
+	assert.Equal("SELECT * FROM `inferred_types_model_stubs` WHERE published_at >= ? AND views >= ? AND `inferred_types_model_stubs`.`deleted_at` IS NULL LIMIT 10", r.Statement.SQL.String())
+
+	for param, fieldType := range map[string]string{
+		"ID":           "number",
+		"title":        "string",
+		"views":        "number",
+		"Score":        "number",
+		"Published":    "bool",
+		"published_at": "time",
+		"Subtitle":     "string",
+		"Rank":         "number",
+		"Featured":     "bool",
+		"deleted_at":   "time",
+		"Visibility":   "enum",
+		"Data":         "default",
+		"slug":         "default",
+	} {
+		assert.Equal(fieldType, q.GetFieldConfig(param).Type, param)
+	}
+}
+
+type AutoFilterModelStub struct {
+	gorm.Model
+	Title      string `json:"title"`
+	ClickCount int64  `json:"clickCount"`
+	Slug       string `json:"slug" gorm:"column:url_slug"`
+	Email      string `json:"email,omitempty" filter:"ops:equal"`
+	Category   string `json:"category" filter:"param:cat"`
+	Secret     string `json:"-"`
+	PrivateBio string `json:"privateBio" filter:"-"`
+	internal   string
+}
+
+func (AutoFilterModelStub) FilterAllFields() bool {
+	return true
+}
+
+type AuthorModelStub struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+type CommentModelStub struct {
+	ID                      uint64 `json:"id"`
+	AssociationsModelStubID uint64 `json:"postId"`
+	Body                    string `json:"body"`
+}
+
+type AssociationsModelStub struct {
+	ID       uint64             `json:"id"`
+	Title    string             `json:"title"`
+	AuthorID uint64             `json:"authorId"`
+	Author   AuthorModelStub    `json:"author"`
+	Comments []CommentModelStub `json:"comments"`
+	Tags     []byte             `json:"tags"`
+}
+
+func (AssociationsModelStub) FilterAllFields() bool {
+	return true
+}
+
+func TestQueryParserFilterAllFields(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should expose exported fields with the json tag names", func(t *testing.T) {
+		q := NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{
+			"clickCount__gte": {"5"},
+			"slug":            {"hello"},
+			"cat":             {"news"},
+			"CreatedAt__gte":  {"2024-03-01"},
+			"secret":          {"x"},
+			"Secret":          {"x"},
+			"privateBio":      {"x"},
+			"internal":        {"x"},
+			"limit":           {"10"},
+		}))
+
+		query, err := ApplyGORM[AutoFilterModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]AutoFilterModelStub{})
+		assert.Equal("SELECT * FROM `auto_filter_model_stubs` WHERE created_at >= ? AND category = ? AND click_count >= ? AND url_slug = ? AND `auto_filter_model_stubs`.`deleted_at` IS NULL LIMIT 10", r.Statement.SQL.String())
+
+		assert.Equal("number", q.GetFieldConfig("ID").Type)
+		assert.Equal("number", q.GetFieldConfig("clickCount").Type)
+		assert.Equal("click_count", q.GetFieldConfig("clickCount").Column())
+		assert.Equal([]string{"equal"}, q.GetFieldConfig("email").Operators)
+		assert.Nil(q.GetFieldConfig("Secret"))
+		assert.Nil(q.GetFieldConfig("privateBio"))
+		assert.Nil(q.GetFieldConfig("internal"))
+	})
+
+	t.Run("Should find records with the exposed fields", func(t *testing.T) {
+		db := GetFakeGormDB()
+		assert.Nil(db.AutoMigrate(&AutoFilterModelStub{}))
+		t.Cleanup(func() {
+			db.Migrator().DropTable(&AutoFilterModelStub{})
+		})
+
+		assert.Nil(db.Create(&[]AutoFilterModelStub{
+			{Title: "first", ClickCount: 1, Slug: "first"},
+			{Title: "second", ClickCount: 10, Slug: "second"},
+		}).Error)
+
+		q := NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{"clickCount__gt": {"5"}, "slug__contains": {"sec"}}))
+
+		query, err := ApplyGORM[AutoFilterModelStub](q, db)
+		assert.Nil(err)
+
+		var records []AutoFilterModelStub
+		assert.Nil(query.Find(&records).Error)
+		assert.Len(records, 1)
+		assert.Equal("second", records[0].Title)
+	})
+
+	t.Run("Should not expose belongs to and has many associations", func(t *testing.T) {
+		db := GetFakeGormDB()
+		assert.Nil(db.AutoMigrate(&AuthorModelStub{}, &AssociationsModelStub{}, &CommentModelStub{}))
+		t.Cleanup(func() {
+			db.Migrator().DropTable(&CommentModelStub{}, &AssociationsModelStub{}, &AuthorModelStub{})
+		})
+
+		assert.Nil(db.Create(&[]AssociationsModelStub{
+			{Title: "first", Author: AuthorModelStub{Name: "a"}, Comments: []CommentModelStub{{Body: "x"}}},
+			{Title: "second", Author: AuthorModelStub{Name: "b"}},
+		}).Error)
+
+		q := NewQuery(50)
+		assert.Nil(q.ParseFromURLValues(url.Values{"author": {"1"}, "comments": {"1"}, "authorId": {"2"}}))
+
+		query, err := ApplyGORM[AssociationsModelStub](q, db)
+		assert.Nil(err)
+
+		var records []AssociationsModelStub
+		assert.Nil(query.Find(&records).Error)
+		assert.Len(records, 1)
+		assert.Equal("second", records[0].Title)
+
+		assert.Nil(q.GetFieldConfig("author"))
+		assert.Nil(q.GetFieldConfig("comments"))
+		assert.NotNil(q.GetFieldConfig("tags"))
+	})
+}
+
+type AliasModelStub struct {
+	ID         uint64 `json:"id"`
+	ClickCount int64  `json:"clickCount" filter:"param:clickCount|click_count|clicks;deprecated:click_count"`
+	Legacy     string `json:"legacy" filter:"param:legacy;type:string;deprecated"`
+	Metadata   string `json:"metadata" filter:"param:metadata|meta;type:json;deprecated:meta"`
+}
+
+type SharedAliasModelStub struct {
+	ID     uint64 `json:"id"`
+	Clicks int64  `json:"clicks" filter:"param:clicks|count"`
+	Views  int64  `json:"views" filter:"param:views|count"`
+}
+
+type AliasOfParamModelStub struct {
+	ID     uint64 `json:"id"`
+	Clicks int64  `json:"clicks" filter:"param:clicks"`
+	Views  int64  `json:"views" filter:"param:views|clicks"`
+}
+
+func TestQueryParserParamAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	type report struct {
+		field string
+		param string
+	}
+
+	var reports []report
+	hook := func(ctx context.Context, field *ModelFieldTagConfig, paramName string) {
+		reports = append(reports, report{field.FieldName, paramName})
+	}
+
+	t.Run("Should filter with the param aliases and report the deprecated ones", func(t *testing.T) {
+		reports = nil
+		parsedURL, _ := url.Parse("https://example.com/example?click_count__gte=5&clicks__lt=10&legacy=a&meta__user.name=b&limit=10")
+
+		q := NewQuery(50, WithDeprecationHook(hook))
+		err := q.ParseFromURLValues(parsedURL.Query())
+		assert.Nil(err)
+
+		query, err := ApplyGORM[AliasModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+
+		r := query.Find(&[]AliasModelStub{})
+		assert.Equal("SELECT * FROM `alias_model_stubs` WHERE clickCount >= ? AND clickCount < ? AND legacy = ? AND CAST(json_extract(metadata, '$.user.name') AS TEXT) = ? LIMIT 10", r.Statement.SQL.String())
+		assert.Equal([]report{{"ClickCount", "click_count"}, {"Legacy", "legacy"}, {"Metadata", "meta"}}, reports)
+
+		assert.Equal([]string{"click_count", "clicks"}, q.GetFieldConfig("clickCount").Aliases)
+		assert.Equal("clickCount", q.GetFieldConfig("clicks").Param)
+	})
+
+	t.Run("Should not report the canonical param names", func(t *testing.T) {
+		reports = nil
+
+		q := NewQuery(50)
+		q.SetDeprecationHook(hook)
+		assert.Nil(q.ParseFromURLValues(url.Values{"clickCount": {"5"}, "metadata__user.name": {"b"}}))
+
+		_, err := ApplyGORM[AliasModelStub](q, GetFakeGormDB().Session(&gorm.Session{DryRun: true}))
+		assert.Nil(err)
+		assert.Empty(reports)
+	})
+	t.Run("Should return an error for params and aliases used in more than one field", func(t *testing.T) {
+		db := GetFakeGormDB().Session(&gorm.Session{DryRun: true})
+
+		_, err := ApplyGORM[SharedAliasModelStub](NewQuery(50), db)
+		assert.ErrorIs(err, ErrDuplicatedParam)
+		assert.Contains(err.Error(), "count in SharedAliasModelStub.Clicks and SharedAliasModelStub.Views")
+
+		_, err = ApplyGORM[AliasOfParamModelStub](NewQuery(50), db)
+		assert.ErrorIs(err, ErrDuplicatedParam)
+		assert.Contains(err.Error(), "clicks in AliasOfParamModelStub.Clicks and AliasOfParamModelStub.Views")
 	})
 }
@@ -4,4 +4,25 @@ import "errors"
 
 var (
 	ErrInvalidQueryOperator = errors.New("query parser: invalid query operator")
+	ErrOperatorNotAllowed   = errors.New("query parser: operator not allowed for this param")
+	ErrFilterNotAllowed     = errors.New("query parser: filter not allowed")
+	ErrQueryTooComplex      = errors.New("query parser: query too complex")
+	ErrInvalidQueryValue    = errors.New("query parser: invalid query value")
+	ErrInvalidOperator      = errors.New("query parser: operator must have a name without separator and a build function")
+	ErrUnknownFieldType     = errors.New("query parser: unknown field type")
+	ErrInvalidSearchConfig  = errors.New("query parser: invalid search filter config")
+	ErrUnsupportedDialect   = errors.New("query parser: unsupported database dialect")
+	ErrInvalidModel         = errors.New("query parser: model must be a pointer to a struct")
+	ErrInvalidDBQuery       = errors.New("query parser: unsupported database query type")
+	ErrInvalidEnumConfig    = errors.New("query parser: enum filter without values")
+	ErrDuplicatedParam      = errors.New("query parser: param or alias used in more than one field")
 )
+
+// IsValidationError returns true for errors caused by invalid client query params
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidQueryOperator) ||
+		errors.Is(err, ErrInvalidQueryValue) ||
+		errors.Is(err, ErrOperatorNotAllowed) ||
+		errors.Is(err, ErrFilterNotAllowed) ||
+		errors.Is(err, ErrQueryTooComplex)
+}
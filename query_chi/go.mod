@@ -0,0 +1,20 @@
+module github.com/go-bolo/query_parser_to_db/query_chi
+
+go 1.19
+
+require (
+	github.com/go-bolo/query_parser_to_db v0.0.0-20261019004553-6b6d8f940baf
+	github.com/go-chi/chi/v5 v5.0.8
+	github.com/stretchr/testify v1.8.1
+	gorm.io/driver/sqlite v1.3.1
+	gorm.io/gorm v1.23.8
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-sqlite3 v1.14.9 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
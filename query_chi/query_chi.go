@@ -0,0 +1,31 @@
+// Package query_chi binds the query parser to Chi routers, that use net/http handlers
+package query_chi
+
+import (
+	"net/http"
+
+	qp "github.com/go-bolo/query_parser_to_db"
+	"gorm.io/gorm"
+)
+
+// Middleware parses the request query params once and stores the query in the request context.
+// Invalid queries respond with a 400 {"error": "message"} JSON, unless cfg.ErrorRenderer is set
+func Middleware(cfg qp.MiddlewareConfig) func(next http.Handler) http.Handler {
+	if cfg.ErrorRenderer == nil {
+		cfg.ErrorRenderer = func(w http.ResponseWriter, req *http.Request, err error) {
+			qp.WriteJSONError(w, http.StatusBadRequest, err)
+		}
+	}
+
+	return qp.NewMiddleware(cfg)
+}
+
+// FromContext returns the query stored by Middleware or nil if not found
+func FromContext(req *http.Request) qp.QueryInterface {
+	return qp.FromContext(req.Context())
+}
+
+// ListHandler returns one handler that responds with the qp.ListResponse of the model T
+func ListHandler[T any](db *gorm.DB, cfg qp.MiddlewareConfig) http.HandlerFunc {
+	return qp.NewListHandler[T](db, cfg)
+}
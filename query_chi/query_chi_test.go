@@ -0,0 +1,73 @@
+package query_chi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qp "github.com/go-bolo/query_parser_to_db"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gorm_logger "gorm.io/gorm/logger"
+)
+
+type PostModelStub struct {
+	ID    uint64 `json:"id" filter:"param:id;type:number"`
+	Title string `json:"title" filter:"param:title;type:string"`
+}
+
+func GetFakeGormDB(t *testing.T) *gorm.DB {
+	d, err := gorm.Open(sqlite.Open("file:query_chi_test?mode=memory&cache=shared"), &gorm.Config{
+		Logger: gorm_logger.Default.LogMode(gorm_logger.Warn),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, d.AutoMigrate(&PostModelStub{}))
+	assert.Nil(t, d.Create(&[]PostModelStub{{Title: "Hello 1"}, {Title: "Hello 2"}, {Title: "Bye"}}).Error)
+
+	return d
+}
+
+func TestChiIntegration(t *testing.T) {
+	assert := assert.New(t)
+	db := GetFakeGormDB(t)
+	cfg := qp.MiddlewareConfig{LimitMax: 50, DefaultLimit: 10}
+
+	r := chi.NewRouter()
+	r.Use(Middleware(cfg))
+	r.Get("/posts", ListHandler[PostModelStub](db, cfg))
+	r.Get("/query", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(FromContext(req).Encode()))
+	})
+
+	t.Run("Should bind the query in the request context", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query?title__contains=Hello&page=2", nil))
+
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.Equal("limit=10&page=2&title__contains=Hello", rec.Body.String())
+	})
+
+	t.Run("Should respond with 400 for invalid queries", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query?limit=abc", nil))
+
+		assert.Equal(http.StatusBadRequest, rec.Code)
+		assert.JSONEq(`{"error": "query parser: invalid query operator"}`, rec.Body.String())
+	})
+
+	t.Run("Should respond with the list envelope", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts?title__starts-with=Hello", nil))
+
+		assert.Equal(http.StatusOK, rec.Code)
+
+		resp := qp.ListResponse[PostModelStub]{}
+		assert.Nil(json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(int64(2), resp.Meta.Count)
+		assert.Equal(2, len(resp.Records))
+		assert.Equal("/posts?limit=10&page=1&title__starts-with=Hello", resp.Meta.Links.First)
+	})
+}
@@ -0,0 +1,29 @@
+module github.com/go-bolo/query_parser_to_db/query_echo
+
+go 1.19
+
+require (
+	github.com/go-bolo/query_parser_to_db v0.0.0-20261019004553-6b6d8f940baf
+	github.com/labstack/echo/v4 v4.10.2
+	github.com/stretchr/testify v1.8.1
+	gorm.io/driver/sqlite v1.3.1
+	gorm.io/gorm v1.23.8
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/labstack/gommon v0.4.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/mattn/go-sqlite3 v1.14.9 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/crypto v0.6.0 // indirect
+	golang.org/x/net v0.7.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+	golang.org/x/text v0.7.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
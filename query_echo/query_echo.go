@@ -0,0 +1,77 @@
+// Package query_echo binds the query parser to Echo handlers
+package query_echo
+
+import (
+	"net/http"
+
+	qp "github.com/go-bolo/query_parser_to_db"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// ContextKey is the echo.Context key where Middleware stores the query
+const ContextKey = "query"
+
+// Middleware parses the request query params once and stores the query in the echo.Context
+// and in the request context. Invalid queries return an echo.HTTPError with status 400
+func Middleware(cfg qp.MiddlewareConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			q, err := Bind(c, cfg)
+			if err != nil {
+				return err
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(qp.NewContext(req.Context(), q)))
+
+			return next(c)
+		}
+	}
+}
+
+// Bind parses the echo.Context query params in a new query and stores it in the echo.Context
+func Bind(c echo.Context, cfg qp.MiddlewareConfig) (qp.QueryInterface, error) {
+	q, err := cfg.ParseRequest(c.Request())
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	c.Set(ContextKey, q)
+
+	return q, nil
+}
+
+// FromContext returns the query stored by Middleware or nil if not found
+func FromContext(c echo.Context) qp.QueryInterface {
+	q, _ := c.Get(ContextKey).(qp.QueryInterface)
+	return q
+}
+
+// ListHandler returns one echo handler that responds with the qp.ListResponse of the model T
+func ListHandler[T any](db *gorm.DB, cfg qp.MiddlewareConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		q := FromContext(c)
+		if q == nil {
+			var err error
+			q, err = Bind(c, cfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		resp, err := qp.FindList[T](q, db.WithContext(c.Request().Context()), c.Request().URL.Path)
+		if err != nil {
+			if qp.IsValidationError(err) {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+			}
+			return err
+		}
+
+		if resp.Meta.Links != nil {
+			c.Response().Header().Set("Link", resp.Meta.Links.LinkHeader())
+		}
+
+		return c.JSON(http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,82 @@
+// Package query_gin binds the query parser to Gin handlers
+package query_gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	qp "github.com/go-bolo/query_parser_to_db"
+	"gorm.io/gorm"
+)
+
+// ContextKey is the gin.Context key where Middleware stores the query
+const ContextKey = "query"
+
+// Middleware parses the request query params once and stores the query in the gin.Context
+// and in the request context. Invalid queries abort with a 400 {"error": "message"} response
+func Middleware(cfg qp.MiddlewareConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q, err := Bind(c, cfg)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Request = c.Request.WithContext(qp.NewContext(c.Request.Context(), q))
+		c.Next()
+	}
+}
+
+// Bind parses the gin.Context query params in a new query and stores it in the gin.Context
+func Bind(c *gin.Context, cfg qp.MiddlewareConfig) (qp.QueryInterface, error) {
+	q, err := cfg.ParseRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Set(ContextKey, q)
+
+	return q, nil
+}
+
+// FromContext returns the query stored by Middleware or nil if not found
+func FromContext(c *gin.Context) qp.QueryInterface {
+	v, _ := c.Get(ContextKey)
+	q, _ := v.(qp.QueryInterface)
+	return q
+}
+
+// ListHandler returns one gin handler that responds with the qp.ListResponse of the model T.
+// Errors are added in c.Errors, only validation errors send the message in the response
+func ListHandler[T any](db *gorm.DB, cfg qp.MiddlewareConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q := FromContext(c)
+		if q == nil {
+			var err error
+			q, err = Bind(c, cfg)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		resp, err := qp.FindList[T](q, db.WithContext(c.Request.Context()), c.Request.URL.Path)
+		if err != nil {
+			c.Error(err)
+			if qp.IsValidationError(err) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			// database errors can have table names and SQL, they are only in c.Errors for the gin logger:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+			return
+		}
+
+		if resp.Meta.Links != nil {
+			c.Header("Link", resp.Meta.Links.LinkHeader())
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
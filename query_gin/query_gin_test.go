@@ -0,0 +1,91 @@
+package query_gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	qp "github.com/go-bolo/query_parser_to_db"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gorm_logger "gorm.io/gorm/logger"
+)
+
+// MissingModelStub has no table in the test database
+type MissingModelStub struct {
+	ID uint64 `json:"id"`
+}
+
+type PostModelStub struct {
+	ID    uint64 `json:"id" filter:"param:id;type:number"`
+	Title string `json:"title" filter:"param:title;type:string"`
+}
+
+func GetFakeGormDB(t *testing.T) *gorm.DB {
+	d, err := gorm.Open(sqlite.Open("file:query_gin_test?mode=memory&cache=shared"), &gorm.Config{
+		Logger: gorm_logger.Default.LogMode(gorm_logger.Warn),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, d.AutoMigrate(&PostModelStub{}))
+	assert.Nil(t, d.Create(&[]PostModelStub{{Title: "Hello 1"}, {Title: "Hello 2"}, {Title: "Bye"}}).Error)
+
+	return d
+}
+
+func TestGinIntegration(t *testing.T) {
+	assert := assert.New(t)
+	db := GetFakeGormDB(t)
+	cfg := qp.MiddlewareConfig{LimitMax: 50, DefaultLimit: 10}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/posts", Middleware(cfg), ListHandler[PostModelStub](db, cfg))
+	r.GET("/missing", func(c *gin.Context) {
+		c.Next()
+		assert.Contains(c.Errors.String(), "no such table: missing_model_stubs")
+	}, ListHandler[MissingModelStub](db, cfg))
+	r.GET("/query", Middleware(cfg), func(c *gin.Context) {
+		q := FromContext(c)
+		assert.Equal(q, qp.FromContext(c.Request.Context()))
+		c.String(http.StatusOK, q.Encode())
+	})
+
+	t.Run("Should bind the query in the gin context", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query?title__contains=Hello&page=2", nil))
+
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.Equal("limit=10&page=2&title__contains=Hello", rec.Body.String())
+	})
+
+	t.Run("Should respond with 400 for invalid queries", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/query?limit=abc", nil))
+
+		assert.Equal(http.StatusBadRequest, rec.Code)
+		assert.JSONEq(`{"error": "query parser: invalid query operator"}`, rec.Body.String())
+	})
+
+	t.Run("Should respond with the list envelope", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts?title__starts-with=Hello", nil))
+
+		assert.Equal(http.StatusOK, rec.Code)
+
+		resp := qp.ListResponse[PostModelStub]{}
+		assert.Nil(json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(int64(2), resp.Meta.Count)
+		assert.Equal(2, len(resp.Records))
+		assert.Equal("/posts?limit=10&page=1&title__starts-with=Hello", resp.Meta.Links.First)
+	})
+	t.Run("Should respond with one generic 500 for database errors", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		assert.Equal(http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(`{"error": "Internal Server Error"}`, rec.Body.String())
+	})
+}
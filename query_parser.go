@@ -1,9 +1,153 @@
 package query_parser_to_db
 
-func NewQuery(limitMax int64) QueryInterface {
+import "time"
+
+// QueryOption configures the Query created in NewQuery
+type QueryOption func(q *Query)
+
+// WithLimitMax sets the max page size accepted in the limit param, it replaces the NewQuery limitMax
+func WithLimitMax(max int64) QueryOption {
+	return func(q *Query) {
+		q.LimitMax = max
+	}
+}
+
+// WithDefaultLimit sets the page size used when the client don't send a valid limit
+func WithDefaultLimit(limit int64) QueryOption {
+	return func(q *Query) {
+		q.DefaultLimit = limit
+	}
+}
+
+// WithoutPagination disables the limit and page params and the pagination in database queries, for internal callers
+func WithoutPagination() QueryOption {
+	return func(q *Query) {
+		q.Unpaginated = true
+	}
+}
+
+// WithLimitParams sets the reserved limit param names, like limit and per_page. The first one is used in Encode
+func WithLimitParams(names ...string) QueryOption {
+	return func(q *Query) {
+		q.LimitParams = names
+	}
+}
+
+// WithPageParams sets the reserved page param names, use it if your models have one page field.
+// The first one is used in Encode and pagination links
+func WithPageParams(names ...string) QueryOption {
+	return func(q *Query) {
+		q.PageParams = names
+	}
+}
+
+// WithOffsetParams sets the reserved offset param names. The first one is used in Encode and pagination links
+func WithOffsetParams(names ...string) QueryOption {
+	return func(q *Query) {
+		q.OffsetParams = names
+	}
+}
+
+// WithTimezoneParams sets the reserved time zone param names. The first one is used in Encode and pagination links
+func WithTimezoneParams(names ...string) QueryOption {
+	return func(q *Query) {
+		q.TimezoneParams = names
+	}
+}
+
+// WithOffsetMax sets the max offset accepted in the offset param, 0 for no max
+func WithOffsetMax(max int64) QueryOption {
+	return func(q *Query) {
+		q.OffsetMax = max
+	}
+}
+
+// WithSeparator sets the separator between param names and operators, like title__contains
+func WithSeparator(separator string) QueryOption {
+	return func(q *Query) {
+		q.Separator = separator
+	}
+}
+
+// WithDefaultOperator sets the operator used in params without operator suffix
+func WithDefaultOperator(operator string) QueryOption {
+	return func(q *Query) {
+		q.DefaultOperator = operator
+	}
+}
+
+// WithSearchParam sets the global free-text param name, like search
+func WithSearchParam(name string) QueryOption {
+	return func(q *Query) {
+		q.SearchParam = name
+	}
+}
+
+// WithMaxSearchTerms sets the max number of words in the search param
+func WithMaxSearchTerms(max int) QueryOption {
+	return func(q *Query) {
+		q.MaxSearchTerms = max
+	}
+}
+
+// WithClock sets the current time used in relative dates like now-7d, for deterministic tests
+func WithClock(clock func() time.Time) QueryOption {
+	return func(q *Query) {
+		q.Clock = clock
+	}
+}
+
+// WithLocation sets the location of relative dates and dates without offset
+func WithLocation(location *time.Location) QueryOption {
+	return func(q *Query) {
+		q.Location = location
+	}
+}
+
+// WithParseMode sets how invalid limit and page params are handled
+func WithParseMode(mode ParseMode) QueryOption {
+	return func(q *Query) {
+		q.Strict = mode == ParseModeStrict
+	}
+}
+
+// WithFilterPolicy sets the policy that decides if one client filter is allowed
+func WithFilterPolicy(policy FilterPolicy) QueryOption {
+	return func(q *Query) {
+		q.FilterPolicy = policy
+	}
+}
+
+// WithDeprecationHook sets the hook that reports filters with deprecated fields or param names
+func WithDeprecationHook(hook DeprecationHook) QueryOption {
+	return func(q *Query) {
+		q.DeprecationHook = hook
+	}
+}
+
+// WithLimits sets the query complexity limits
+func WithLimits(limits QueryLimits) QueryOption {
+	return func(q *Query) {
+		q.Limits = limits
+	}
+}
+
+// NewQuery returns a new query with limit between 1 and limitMax, inclusive.
+// limitMax is kept as the first argument for the existing callers, use 0 and WithLimitMax to set it with the options
+func NewQuery(limitMax int64, opts ...QueryOption) QueryInterface {
+	return newQuery(limitMax, opts...)
+}
+
+func newQuery(limitMax int64, opts ...QueryOption) *Query {
 	q := Query{
 		LimitMax: limitMax,
 	}
 
+	for _, opt := range opts {
+		opt(&q)
+	}
+
+	q.SetLimit(0)
+
 	return &q
 }